@@ -0,0 +1,259 @@
+package event
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// AccountAuth is an account with its weight in an authority
+type AccountAuth struct {
+	Account string `json:"account"`
+	Weight  uint16 `json:"weight"`
+}
+
+// KeyAuth is a public key with its weight in an authority
+type KeyAuth struct {
+	Key    string `json:"key"`
+	Weight uint16 `json:"weight"`
+}
+
+// Authority is a weighted set of accounts and keys, the auths are sorted so authorities can be compared with Equal
+type Authority struct {
+	WeightThreshold uint32        `json:"weight_threshold"`
+	AccountAuths    []AccountAuth `json:"account_auths"`
+	KeyAuths        []KeyAuth     `json:"key_auths"`
+}
+
+// Equal returns whether both authorities have the same threshold, accounts and keys with the same weights
+func (a Authority) Equal(a2 Authority) bool {
+	if a.WeightThreshold != a2.WeightThreshold ||
+		len(a.AccountAuths) != len(a2.AccountAuths) ||
+		len(a.KeyAuths) != len(a2.KeyAuths) {
+		return false
+	}
+
+	for i := range a.AccountAuths {
+		if a.AccountAuths[i] != a2.AccountAuths[i] {
+			return false
+		}
+	}
+
+	for i := range a.KeyAuths {
+		if a.KeyAuths[i] != a2.KeyAuths[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toAuthority(a types.Authority) Authority {
+	authority := Authority{
+		WeightThreshold: a.WeightThreshold,
+	}
+
+	if a.AccountAuths != nil && a.AccountAuths.OrderedMap != nil {
+		for el := a.AccountAuths.Front(); el != nil; el = el.Next() {
+			authority.AccountAuths = append(authority.AccountAuths, AccountAuth{
+				Account: fmt.Sprint(el.Key),
+				Weight:  weight(el.Value),
+			})
+		}
+		sort.Slice(authority.AccountAuths, func(i, j int) bool {
+			return authority.AccountAuths[i].Account < authority.AccountAuths[j].Account
+		})
+	}
+
+	if a.KeyAuths != nil && a.KeyAuths.OrderedMap != nil {
+		for el := a.KeyAuths.Front(); el != nil; el = el.Next() {
+			authority.KeyAuths = append(authority.KeyAuths, KeyAuth{
+				Key:    fmt.Sprint(el.Key),
+				Weight: weight(el.Value),
+			})
+		}
+		sort.Slice(authority.KeyAuths, func(i, j int) bool {
+			return authority.KeyAuths[i].Key < authority.KeyAuths[j].Key
+		})
+	}
+
+	return authority
+}
+
+// weight converts a weight of an authority map, the maps keep uint16 weights
+func weight(v interface{}) uint16 {
+	w, _ := v.(uint16)
+	return w
+}
+
+// toOptionalAuthority returns nil for an authority absent in the operation
+func toOptionalAuthority(a types.Authority) *Authority {
+	if a.WeightThreshold == 0 && a.AccountAuths == nil && a.KeyAuths == nil {
+		return nil
+	}
+
+	authority := toAuthority(a)
+	return &authority
+}
+
+// AccountUpdateEvent, the authorities not changed by the operation are nil.
+// The operation carries the new authorities only: the provider leaves the Previous fields empty,
+// they are only set by projection.Authorities from the ones it has seen before, nil or empty when unknown.
+// KeyRotated is false for an event that didn't go through projection.Authorities.
+type AccountUpdateEvent struct {
+	Account         string     `json:"account"`
+	Owner           *Authority `json:"owner"`
+	Active          *Authority `json:"active"`
+	Posting         *Authority `json:"posting"`
+	MemoKey         string     `json:"memo_key"`
+	JsonMetadata    string     `json:"json_metadata"`
+	PreviousOwner   *Authority `json:"previous_owner"`
+	PreviousActive  *Authority `json:"previous_active"`
+	PreviousPosting *Authority `json:"previous_posting"`
+	PreviousMemoKey string     `json:"previous_memo_key"`
+}
+
+func (e AccountUpdateEvent) Type() Type {
+	return AccountUpdateEventType
+}
+
+// KeyRotated returns whether an authority or the memo key changed from its known previous value
+func (e AccountUpdateEvent) KeyRotated() bool {
+	return changed(e.PreviousOwner, e.Owner) || changed(e.PreviousActive, e.Active) || changed(e.PreviousPosting, e.Posting) ||
+		e.PreviousMemoKey != "" && e.MemoKey != "" && e.PreviousMemoKey != e.MemoKey
+}
+
+// changed returns whether both authorities are known and differ
+func changed(previous, authority *Authority) bool {
+	return previous != nil && authority != nil && !previous.Equal(*authority)
+}
+
+func toAccountUpdateEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.AccountUpdateOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return &AccountUpdateEvent{
+		Account:      v.Account,
+		Owner:        toOptionalAuthority(v.Owner),
+		Active:       toOptionalAuthority(v.Active),
+		Posting:      toOptionalAuthority(v.Posting),
+		MemoKey:      string(v.MemoKey),
+		JsonMetadata: v.JsonMetadata,
+	}, nil
+}
+
+// RequestAccountRecoveryEvent
+type RequestAccountRecoveryEvent struct {
+	RecoveryAccount   string    `json:"recovery_account"`
+	AccountToRecover  string    `json:"account_to_recover"`
+	NewOwnerAuthority Authority `json:"new_owner_authority"`
+}
+
+func (e RequestAccountRecoveryEvent) Type() Type {
+	return RequestAccountRecoveryEventType
+}
+
+func toRequestAccountRecoveryEvent(op types.Operation) (Event, error) {
+	var v struct {
+		RecoveryAccount   string          `json:"recovery_account"`
+		AccountToRecover  string          `json:"account_to_recover"`
+		NewOwnerAuthority types.Authority `json:"new_owner_authority"`
+	}
+	if err := unmarshalUnknownOperation(op, &v); err != nil {
+		return nil, err
+	}
+
+	return &RequestAccountRecoveryEvent{
+		RecoveryAccount:   v.RecoveryAccount,
+		AccountToRecover:  v.AccountToRecover,
+		NewOwnerAuthority: toAuthority(v.NewOwnerAuthority),
+	}, nil
+}
+
+// RecoverAccountEvent
+type RecoverAccountEvent struct {
+	AccountToRecover     string    `json:"account_to_recover"`
+	NewOwnerAuthority    Authority `json:"new_owner_authority"`
+	RecentOwnerAuthority Authority `json:"recent_owner_authority"`
+}
+
+func (e RecoverAccountEvent) Type() Type {
+	return RecoverAccountEventType
+}
+
+func toRecoverAccountEvent(op types.Operation) (Event, error) {
+	var v struct {
+		AccountToRecover     string          `json:"account_to_recover"`
+		NewOwnerAuthority    types.Authority `json:"new_owner_authority"`
+		RecentOwnerAuthority types.Authority `json:"recent_owner_authority"`
+	}
+	if err := unmarshalUnknownOperation(op, &v); err != nil {
+		return nil, err
+	}
+
+	return &RecoverAccountEvent{
+		AccountToRecover:     v.AccountToRecover,
+		NewOwnerAuthority:    toAuthority(v.NewOwnerAuthority),
+		RecentOwnerAuthority: toAuthority(v.RecentOwnerAuthority),
+	}, nil
+}
+
+// ChangeRecoveryAccountEvent
+type ChangeRecoveryAccountEvent struct {
+	AccountToRecover   string `json:"account_to_recover"`
+	NewRecoveryAccount string `json:"new_recovery_account"`
+}
+
+func (e ChangeRecoveryAccountEvent) Type() Type {
+	return ChangeRecoveryAccountEventType
+}
+
+func toChangeRecoveryAccountEvent(op types.Operation) (Event, error) {
+	var e ChangeRecoveryAccountEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// DeclineVotingRightsEvent, Decline is false when a pending request is cancelled
+type DeclineVotingRightsEvent struct {
+	Account string `json:"account"`
+	Decline bool   `json:"decline"`
+}
+
+func (e DeclineVotingRightsEvent) Type() Type {
+	return DeclineVotingRightsEventType
+}
+
+func toDeclineVotingRightsEvent(op types.Operation) (Event, error) {
+	var e DeclineVotingRightsEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ProveAuthorityEvent
+type ProveAuthorityEvent struct {
+	Challenged   string `json:"challenged"`
+	RequireOwner bool   `json:"require_owner"`
+}
+
+func (e ProveAuthorityEvent) Type() Type {
+	return ProveAuthorityEventType
+}
+
+func toProveAuthorityEvent(op types.Operation) (Event, error) {
+	var e ProveAuthorityEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,137 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	key1 = "SCR5jPZF7PMgTpLqkdfpMu8kXea8Gio6E646aYpTgcjr9qMLrAgnL"
+	key2 = "SCR6oVhXuUhLkFs6Eovt7ZMxfhnmzgcXhDxrGWYCdZGxSrv3HvUPk"
+)
+
+func TestAccountUpdateEvent(t *testing.T) {
+	var op types.AccountUpdateOperation
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"account": "alice",
+		"active": {"weight_threshold": 1, "account_auths": [["bob", 1]], "key_auths": [["`+key2+`", 1], ["`+key1+`", 1]]},
+		"memo_key": "`+key1+`",
+		"json_metadata": "{}"
+	}`), &op))
+
+	ev, err := toAccountUpdateEvent(&op)
+	require.NoError(t, err)
+
+	update := ev.(*AccountUpdateEvent)
+	require.Equal(t, "alice", update.Account)
+	require.Nil(t, update.Owner)
+	require.Nil(t, update.Posting)
+	require.Equal(t, key1, update.MemoKey)
+	require.Equal(t, &Authority{
+		WeightThreshold: 1,
+		AccountAuths:    []AccountAuth{{Account: "bob", Weight: 1}},
+		KeyAuths:        []KeyAuth{{Key: key1, Weight: 1}, {Key: key2, Weight: 1}},
+	}, update.Active)
+
+	// the previous authorities are unknown to the converter
+	require.Nil(t, update.PreviousActive)
+	require.False(t, update.KeyRotated())
+}
+
+func TestAuthority_Equal(t *testing.T) {
+	a := toAuthority(types.Authority{
+		WeightThreshold: 1,
+		KeyAuths:        types.NewKeyAuthorityMap(types.KeyAuthority{Key: key1, Weight: 1}, types.KeyAuthority{Key: key2, Weight: 1}),
+	})
+	b := toAuthority(types.Authority{
+		WeightThreshold: 1,
+		KeyAuths:        types.NewKeyAuthorityMap(types.KeyAuthority{Key: key2, Weight: 1}, types.KeyAuthority{Key: key1, Weight: 1}),
+	})
+	rotated := toAuthority(types.Authority{
+		WeightThreshold: 1,
+		KeyAuths:        types.NewKeyAuthorityMap(types.KeyAuthority{Key: key1, Weight: 1}),
+	})
+
+	require.True(t, a.Equal(b))
+	require.False(t, a.Equal(rotated))
+}
+
+func TestAccountSecurityEvents(t *testing.T) {
+	owner := Authority{WeightThreshold: 1, KeyAuths: []KeyAuth{{Key: key1, Weight: 1}}}
+	recent := Authority{WeightThreshold: 1, KeyAuths: []KeyAuth{{Key: key2, Weight: 1}}}
+
+	testConverters(t, []converterCase{
+		{
+			name:      "request account recovery",
+			converter: toRequestAccountRecoveryEvent,
+			op: rawOperation(`{"recovery_account": "bob", "account_to_recover": "alice",
+				"new_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key1 + `", 1]]}, "extensions": []}`),
+			expected: &RequestAccountRecoveryEvent{RecoveryAccount: "bob", AccountToRecover: "alice", NewOwnerAuthority: owner},
+		},
+		{
+			name:      "recover account",
+			converter: toRecoverAccountEvent,
+			op: rawOperation(`{"account_to_recover": "alice",
+				"new_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key1 + `", 1]]},
+				"recent_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key2 + `", 1]]}, "extensions": []}`),
+			expected: &RecoverAccountEvent{AccountToRecover: "alice", NewOwnerAuthority: owner, RecentOwnerAuthority: recent},
+		},
+		{
+			name:      "request account recovery without extensions",
+			converter: toRequestAccountRecoveryEvent,
+			op: rawOperation(`{"recovery_account": "bob", "account_to_recover": "alice",
+				"new_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key1 + `", 1]]}}`),
+			expected: &RequestAccountRecoveryEvent{RecoveryAccount: "bob", AccountToRecover: "alice", NewOwnerAuthority: owner},
+		},
+		{
+			name:      "change recovery account",
+			converter: toChangeRecoveryAccountEvent,
+			op:        rawOperation(`{"account_to_recover": "alice", "new_recovery_account": "bob", "extensions": []}`),
+			expected:  &ChangeRecoveryAccountEvent{AccountToRecover: "alice", NewRecoveryAccount: "bob"},
+		},
+		{
+			name:      "decline voting rights",
+			converter: toDeclineVotingRightsEvent,
+			op:        rawOperation(`{"account": "alice", "decline": true}`),
+			expected:  &DeclineVotingRightsEvent{Account: "alice", Decline: true},
+		},
+		{
+			name:      "prove authority",
+			converter: toProveAuthorityEvent,
+			op:        rawOperation(`{"challenged": "alice", "require_owner": false}`),
+			expected:  &ProveAuthorityEvent{Challenged: "alice"},
+		},
+	})
+}
+
+func TestAccountSecurityEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "malformed authority",
+			converter: toRecoverAccountEvent,
+			op:        rawOperation(`{"account_to_recover": "alice", "new_owner_authority": {"weight_threshold": "one"}}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "malformed decline",
+			converter: toDeclineVotingRightsEvent,
+			op:        rawOperation(`{"account": "alice", "decline": "yes"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "raw operation of a scorum-go operation",
+			converter: toAccountUpdateEvent,
+			op:        rawOperation(`{"account": "alice"}`),
+			err:       ErrWrongOperationType,
+		},
+		{
+			name:      "scorum-go operation of a raw operation",
+			converter: toProveAuthorityEvent,
+			op:        &types.AccountUpdateOperation{Account: "alice"},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
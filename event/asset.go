@@ -0,0 +1,62 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+const (
+	SCRSymbol = "SCR"
+	SPSymbol  = "SP"
+)
+
+// Asset is an amount of SCR or SP as it is formatted by the node, e.g. "1.000000000 SP"
+type Asset struct {
+	types.Asset
+	Symbol string
+}
+
+// ParseAsset parses an amount followed by its symbol
+func ParseAsset(value string) (Asset, error) {
+	parts := strings.Fields(value)
+	if len(parts) != 2 {
+		return Asset{}, fmt.Errorf("can't convert %s to asset", value)
+	}
+
+	amount, err := types.AssetFromString(parts[0])
+	if err != nil {
+		return Asset{}, err
+	}
+
+	return Asset{Asset: *amount, Symbol: parts[1]}, nil
+}
+
+func (a Asset) String() string {
+	return fmt.Sprintf("%s %s", a.Decimal().StringFixed(9), a.Symbol)
+}
+
+func (a Asset) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Asset) UnmarshalText(text []byte) error {
+	parsed, err := ParseAsset(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+	return nil
+}
+
+// parseAsset parses an amount of an operation
+func parseAsset(value string) (Asset, error) {
+	asset, err := ParseAsset(value)
+	if err != nil {
+		return Asset{}, fmt.Errorf("%w: %s", ErrMalformedOperation, err)
+	}
+
+	return asset, nil
+}
@@ -0,0 +1,117 @@
+package event
+
+import (
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// AtomicSwapRole tells whether a contract is initiated by the initiator or the participant of a swap
+type AtomicSwapRole string
+
+const (
+	AtomicSwapByInitiator   AtomicSwapRole = "by_initiator"
+	AtomicSwapByParticipant AtomicSwapRole = "by_participant"
+)
+
+// The contract of the initiator can be refunded after AtomicSwapInitiatorLifetime, the contract of the participant
+// after AtomicSwapParticipantLifetime, so the initiator can redeem the contract of the participant in time
+const (
+	AtomicSwapInitiatorLifetime   = 48 * time.Hour
+	AtomicSwapParticipantLifetime = 24 * time.Hour
+)
+
+// AtomicSwapInitiateEvent, the contract can be redeemed by Recipient with the secret of SecretHash until Deadline.
+// Deadline is computed from the time of the block by SetBlockTime, the provider calls it after converting the operation.
+type AtomicSwapInitiateEvent struct {
+	Role       AtomicSwapRole `json:"type"`
+	Owner      string         `json:"owner"`
+	Recipient  string         `json:"recipient"`
+	Amount     Asset          `json:"amount"`
+	SecretHash string         `json:"secret_hash"`
+	Metadata   string         `json:"metadata"`
+	Deadline   time.Time      `json:"deadline"`
+}
+
+func (e AtomicSwapInitiateEvent) Type() Type {
+	return AtomicSwapInitiateEventType
+}
+
+// SetBlockTime sets the deadline of the contract initiated at the block time by its role
+func (e *AtomicSwapInitiateEvent) SetBlockTime(t time.Time) {
+	switch e.Role {
+	case AtomicSwapByInitiator:
+		e.Deadline = t.Add(AtomicSwapInitiatorLifetime)
+	case AtomicSwapByParticipant:
+		e.Deadline = t.Add(AtomicSwapParticipantLifetime)
+	}
+}
+
+func toAtomicSwapInitiateEvent(op types.Operation) (Event, error) {
+	var e AtomicSwapInitiateEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// AtomicSwapRedeemEvent, the secret revealed by To lets the other party redeem its contract
+type AtomicSwapRedeemEvent struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Secret string `json:"secret"`
+}
+
+func (e AtomicSwapRedeemEvent) Type() Type {
+	return AtomicSwapRedeemEventType
+}
+
+func toAtomicSwapRedeemEvent(op types.Operation) (Event, error) {
+	var e AtomicSwapRedeemEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// AtomicSwapRefundEvent
+type AtomicSwapRefundEvent struct {
+	Participant string `json:"participant"`
+	Initiator   string `json:"initiator"`
+	SecretHash  string `json:"secret_hash"`
+}
+
+func (e AtomicSwapRefundEvent) Type() Type {
+	return AtomicSwapRefundEventType
+}
+
+func toAtomicSwapRefundEvent(op types.Operation) (Event, error) {
+	var e AtomicSwapRefundEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ExpiredContractRefundEvent is produced by the expired_contract_refund virtual operation
+// when an atomic swap contract expires without being redeemed
+type ExpiredContractRefundEvent struct {
+	Owner  string `json:"owner"`
+	Refund Asset  `json:"refund"`
+}
+
+func (e ExpiredContractRefundEvent) Type() Type {
+	return ExpiredContractRefundEventType
+}
+
+func toExpiredContractRefundEvent(op types.Operation) (Event, error) {
+	var e ExpiredContractRefundEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
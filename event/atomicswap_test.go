@@ -0,0 +1,103 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+const secretHash = "0fd9ab1e3cbca1a4e46b4b7fd1d8f5e3b3c3e0d0a1c6b87c7e1a0d6ea4e3b7c1"
+
+func TestAtomicSwapEvents(t *testing.T) {
+	scr := mustParseAsset(t, "10.000000000 SCR")
+
+	testConverters(t, []converterCase{
+		{
+			name:      "atomic swap initiate",
+			converter: toAtomicSwapInitiateEvent,
+			op: rawOperation(`{"type": "by_initiator", "owner": "alice", "recipient": "bob",
+				"amount": "10.000000000 SCR", "secret_hash": "` + secretHash + `", "metadata": "btc"}`),
+			expected: &AtomicSwapInitiateEvent{
+				Role:       AtomicSwapByInitiator,
+				Owner:      "alice",
+				Recipient:  "bob",
+				Amount:     scr,
+				SecretHash: secretHash,
+				Metadata:   "btc",
+			},
+		},
+		{
+			name:      "atomic swap initiate without metadata",
+			converter: toAtomicSwapInitiateEvent,
+			op: rawOperation(`{"type": "by_participant", "owner": "bob", "recipient": "alice",
+				"amount": "10.000000000 SCR", "secret_hash": "` + secretHash + `"}`),
+			expected: &AtomicSwapInitiateEvent{
+				Role:       AtomicSwapByParticipant,
+				Owner:      "bob",
+				Recipient:  "alice",
+				Amount:     scr,
+				SecretHash: secretHash,
+			},
+		},
+		{
+			name:      "atomic swap redeem",
+			converter: toAtomicSwapRedeemEvent,
+			op:        rawOperation(`{"from": "alice", "to": "bob", "secret": "c2VjcmV0"}`),
+			expected:  &AtomicSwapRedeemEvent{From: "alice", To: "bob", Secret: "c2VjcmV0"},
+		},
+		{
+			name:      "atomic swap refund",
+			converter: toAtomicSwapRefundEvent,
+			op:        rawOperation(`{"participant": "bob", "initiator": "alice", "secret_hash": "` + secretHash + `"}`),
+			expected:  &AtomicSwapRefundEvent{Participant: "bob", Initiator: "alice", SecretHash: secretHash},
+		},
+		{
+			name:      "expired contract refund",
+			converter: toExpiredContractRefundEvent,
+			op:        rawOperation(`{"owner": "alice", "refund": "10.000000000 SCR"}`),
+			expected:  &ExpiredContractRefundEvent{Owner: "alice", Refund: scr},
+		},
+	})
+}
+
+func TestAtomicSwapEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "malformed amount",
+			converter: toAtomicSwapInitiateEvent,
+			op:        rawOperation(`{"type": "by_initiator", "owner": "alice", "amount": "ten SCR"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "malformed payload",
+			converter: toAtomicSwapRedeemEvent,
+			op:        rawOperation(`["alice", "bob"]`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "scorum-go operation",
+			converter: toExpiredContractRefundEvent,
+			op:        &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
+
+func TestAtomicSwapInitiateEvent_SetBlockTime(t *testing.T) {
+	blockTime := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	initiator := AtomicSwapInitiateEvent{Role: AtomicSwapByInitiator}
+	initiator.SetBlockTime(blockTime)
+	require.Equal(t, time.Date(2019, 6, 3, 12, 0, 0, 0, time.UTC), initiator.Deadline)
+
+	participant := AtomicSwapInitiateEvent{Role: AtomicSwapByParticipant}
+	participant.SetBlockTime(blockTime)
+	require.Equal(t, time.Date(2019, 6, 2, 12, 0, 0, 0, time.UTC), participant.Deadline)
+
+	// the deadline of an unknown role is unknown
+	unknown := AtomicSwapInitiateEvent{Role: "by_agent"}
+	unknown.SetBlockTime(blockTime)
+	require.True(t, unknown.Deadline.IsZero())
+}
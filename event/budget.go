@@ -0,0 +1,178 @@
+package event
+
+import (
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// BudgetType is the kind of advertising a budget pays for
+type BudgetType string
+
+const (
+	PostBudget   BudgetType = "post"
+	BannerBudget BudgetType = "banner"
+)
+
+// CreateBudgetEvent, the balance is spent on advertising from Start until Deadline
+type CreateBudgetEvent struct {
+	BudgetType   BudgetType `json:"type"`
+	UUID         string     `json:"uuid"`
+	Owner        string     `json:"owner"`
+	JsonMetadata string     `json:"json_metadata"`
+	Balance      Asset      `json:"balance"`
+	Start        time.Time  `json:"start"`
+	Deadline     time.Time  `json:"deadline"`
+}
+
+func (e CreateBudgetEvent) Type() Type {
+	return CreateBudgetEventType
+}
+
+func toCreateBudgetEvent(op types.Operation) (Event, error) {
+	var v struct {
+		BudgetType   BudgetType `json:"type"`
+		UUID         string     `json:"uuid"`
+		Owner        string     `json:"owner"`
+		JsonMetadata string     `json:"json_metadata"`
+		Balance      Asset      `json:"balance"`
+		Start        types.Time `json:"start"`
+		Deadline     types.Time `json:"deadline"`
+	}
+	if err := unmarshalUnknownOperation(op, &v); err != nil {
+		return nil, err
+	}
+
+	return &CreateBudgetEvent{
+		BudgetType:   v.BudgetType,
+		UUID:         v.UUID,
+		Owner:        v.Owner,
+		JsonMetadata: v.JsonMetadata,
+		Balance:      v.Balance,
+		Start:        toTime(v.Start),
+		Deadline:     toTime(v.Deadline),
+	}, nil
+}
+
+// UpdateBudgetEvent
+type UpdateBudgetEvent struct {
+	BudgetType   BudgetType `json:"type"`
+	UUID         string     `json:"uuid"`
+	Owner        string     `json:"owner"`
+	JsonMetadata string     `json:"json_metadata"`
+}
+
+func (e UpdateBudgetEvent) Type() Type {
+	return UpdateBudgetEventType
+}
+
+func toUpdateBudgetEvent(op types.Operation) (Event, error) {
+	var e UpdateBudgetEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CloseBudgetEvent
+type CloseBudgetEvent struct {
+	BudgetType BudgetType `json:"type"`
+	UUID       string     `json:"uuid"`
+	Owner      string     `json:"owner"`
+}
+
+func (e CloseBudgetEvent) Type() Type {
+	return CloseBudgetEventType
+}
+
+func toCloseBudgetEvent(op types.Operation) (Event, error) {
+	var e CloseBudgetEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CloseBudgetByAdvertisingModeratorEvent
+type CloseBudgetByAdvertisingModeratorEvent struct {
+	BudgetType BudgetType `json:"type"`
+	UUID       string     `json:"uuid"`
+	Moderator  string     `json:"moderator"`
+}
+
+func (e CloseBudgetByAdvertisingModeratorEvent) Type() Type {
+	return CloseBudgetByAdvertisingModeratorEventType
+}
+
+func toCloseBudgetByAdvertisingModeratorEvent(op types.Operation) (Event, error) {
+	var e CloseBudgetByAdvertisingModeratorEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// AllocateCashFromAdvertisingBudgetEvent is produced by the allocate_cash_from_advertising_budget virtual operation
+type AllocateCashFromAdvertisingBudgetEvent struct {
+	BudgetType BudgetType `json:"type"`
+	UUID       string     `json:"uuid"`
+	Owner      string     `json:"owner"`
+	Cash       Asset      `json:"cash"`
+}
+
+func (e AllocateCashFromAdvertisingBudgetEvent) Type() Type {
+	return AllocateCashFromAdvertisingBudgetEventType
+}
+
+func toAllocateCashFromAdvertisingBudgetEvent(op types.Operation) (Event, error) {
+	var e AllocateCashFromAdvertisingBudgetEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CashBackFromAdvertisingBudgetToOwnerEvent is produced by the cash_back_from_advertising_budget_to_owner virtual operation
+type CashBackFromAdvertisingBudgetToOwnerEvent struct {
+	BudgetType BudgetType `json:"type"`
+	UUID       string     `json:"uuid"`
+	Owner      string     `json:"owner"`
+	Cash       Asset      `json:"cash"`
+}
+
+func (e CashBackFromAdvertisingBudgetToOwnerEvent) Type() Type {
+	return CashBackFromAdvertisingBudgetToOwnerEventType
+}
+
+func toCashBackFromAdvertisingBudgetToOwnerEvent(op types.Operation) (Event, error) {
+	var e CashBackFromAdvertisingBudgetToOwnerEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ClosingBudgetEvent is produced by the closing_budget virtual operation when a budget is closed for any reason
+type ClosingBudgetEvent struct {
+	BudgetType BudgetType `json:"type"`
+	UUID       string     `json:"uuid"`
+	Owner      string     `json:"owner"`
+}
+
+func (e ClosingBudgetEvent) Type() Type {
+	return ClosingBudgetEventType
+}
+
+func toClosingBudgetEvent(op types.Operation) (Event, error) {
+	var e ClosingBudgetEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,97 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+const budgetUUID = "6f0d8a5e-5b0c-4f8b-9f0e-3a8f1c2d4e5b"
+
+func TestBudgetEvents(t *testing.T) {
+	cash := mustParseAsset(t, "0.500000000 SCR")
+
+	testConverters(t, []converterCase{
+		{
+			name:      "create budget",
+			converter: toCreateBudgetEvent,
+			op: rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "json_metadata": "{}",
+				"balance": "10.000000000 SCR", "start": "2019-01-01T00:00:00", "deadline": "2019-02-01T00:00:00"}`),
+			expected: &CreateBudgetEvent{
+				BudgetType:   PostBudget,
+				UUID:         budgetUUID,
+				Owner:        "alice",
+				JsonMetadata: "{}",
+				Balance:      mustParseAsset(t, "10.000000000 SCR"),
+				Start:        time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				Deadline:     time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "update budget",
+			converter: toUpdateBudgetEvent,
+			op:        rawOperation(`{"type": "banner", "uuid": "` + budgetUUID + `", "owner": "alice", "json_metadata": "{\"url\": \"x\"}"}`),
+			expected:  &UpdateBudgetEvent{BudgetType: BannerBudget, UUID: budgetUUID, Owner: "alice", JsonMetadata: `{"url": "x"}`},
+		},
+		{
+			name:      "update budget without metadata",
+			converter: toUpdateBudgetEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice"}`),
+			expected:  &UpdateBudgetEvent{BudgetType: PostBudget, UUID: budgetUUID, Owner: "alice"},
+		},
+		{
+			name:      "close budget",
+			converter: toCloseBudgetEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice"}`),
+			expected:  &CloseBudgetEvent{BudgetType: PostBudget, UUID: budgetUUID, Owner: "alice"},
+		},
+		{
+			name:      "close budget by advertising moderator",
+			converter: toCloseBudgetByAdvertisingModeratorEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "moderator": "bob"}`),
+			expected:  &CloseBudgetByAdvertisingModeratorEvent{BudgetType: PostBudget, UUID: budgetUUID, Moderator: "bob"},
+		},
+		{
+			name:      "allocate cash from advertising budget",
+			converter: toAllocateCashFromAdvertisingBudgetEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "cash": "0.500000000 SCR"}`),
+			expected:  &AllocateCashFromAdvertisingBudgetEvent{BudgetType: PostBudget, UUID: budgetUUID, Owner: "alice", Cash: cash},
+		},
+		{
+			name:      "cash back from advertising budget to owner",
+			converter: toCashBackFromAdvertisingBudgetToOwnerEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "cash": "0.500000000 SCR"}`),
+			expected:  &CashBackFromAdvertisingBudgetToOwnerEvent{BudgetType: PostBudget, UUID: budgetUUID, Owner: "alice", Cash: cash},
+		},
+		{
+			name:      "closing budget",
+			converter: toClosingBudgetEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice"}`),
+			expected:  &ClosingBudgetEvent{BudgetType: PostBudget, UUID: budgetUUID, Owner: "alice"},
+		},
+	})
+}
+
+func TestBudgetEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "malformed start",
+			converter: toCreateBudgetEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "start": "tomorrow"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "malformed cash",
+			converter: toAllocateCashFromAdvertisingBudgetEvent,
+			op:        rawOperation(`{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "cash": 5}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "scorum-go operation",
+			converter: toCloseBudgetEvent,
+			op:        &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
@@ -0,0 +1,153 @@
+package event
+
+// Category groups the built-in types, so consumers can subscribe by category
+type Category string
+
+const (
+	SocialCategory      Category = "social"
+	BettingCategory     Category = "betting"
+	NFTCategory         Category = "nft"
+	FinanceCategory     Category = "finance"
+	AccountCategory     Category = "account"
+	GovernanceCategory  Category = "governance"
+	AdvertisingCategory Category = "advertising"
+)
+
+// categoryTypes are the types of every category, a type can be in several categories,
+// e.g. content rewards are both social and finance
+var categoryTypes = map[Category][]Type{
+	SocialCategory: {
+		PostEventType,
+		CommentEventType,
+		VoteEventType,
+		FlagEventType,
+		DeleteCommentEventType,
+		CommentOptionsEventType,
+		AuthorRewardEventType,
+		CommentRewardEventType,
+		CurationRewardEventType,
+		CommentBenefactorRewardEventType,
+		CommentPayoutUpdateEventType,
+		VoteCastEventType,
+		VoteChangedEventType,
+		VoteRemovedEventType,
+		FlagCastEventType,
+		FlagRemovedEventType,
+		PostEditedEventType,
+		CommentEditedEventType,
+	},
+	BettingCategory: {
+		CreateGameEventType,
+		CancelGameEventType,
+		UpdateGameStartEventType,
+		UpdateGameMarketsEventType,
+		PostGameResultsEventType,
+		PostBetEventType,
+		CancelPendingBetsEventType,
+		BetsMatchedEventType,
+		GameStatusChangedEventType,
+		BetResolvedEventType,
+		BetCancelledEventType,
+		BetRestoredEventType,
+		BetUpdatedEventType,
+	},
+	NFTCategory: {
+		CreateNFTEventType,
+		UpdateNFTMetadataEventType,
+		AdjustNFTExperienceEventType,
+		UpdateNFTNameEventType,
+		CreateGameRoundEventType,
+		UpdateGameRoundResultEventType,
+	},
+	FinanceCategory: {
+		TransferEventType,
+		BurnEventType,
+		TransferToScorumpowerEventType,
+		WithdrawScorumpowerEventType,
+		DelegateScorumpowerEventType,
+		DelegateSPFromRegPoolEventType,
+		SetWithdrawScorumpowerRouteToAccountEventType,
+		SetWithdrawScorumpowerRouteToDevPoolEventType,
+		FillScorumpowerWithdrawEventType,
+		ReturnScorumpowerDelegationEventType,
+		EscrowTransferEventType,
+		EscrowApproveEventType,
+		EscrowDisputeEventType,
+		EscrowReleaseEventType,
+		AtomicSwapInitiateEventType,
+		AtomicSwapRedeemEventType,
+		AtomicSwapRefundEventType,
+		ExpiredContractRefundEventType,
+		AuthorRewardEventType,
+		CommentRewardEventType,
+		CurationRewardEventType,
+		CommentBenefactorRewardEventType,
+		ActiveSPHoldersRewardLegacyEventType,
+	},
+	AccountCategory: {
+		AccountCreateEventType,
+		AccountUpdateEventType,
+		RequestAccountRecoveryEventType,
+		RecoverAccountEventType,
+		ChangeRecoveryAccountEventType,
+		DeclineVotingRightsEventType,
+		ProveAuthorityEventType,
+	},
+	GovernanceCategory: {
+		HardforkEventType,
+		HardforkVersionChangedEventType,
+		MajorityVersionChangedEventType,
+		ChainPropertiesChangedEventType,
+		WitnessUpdateEventType,
+		AccountWitnessVoteEventType,
+		AccountWitnessProxyEventType,
+		ProposalCreateEventType,
+		ProposalVoteEventType,
+		ProposalVirtualEventType,
+		ShutdownWitnessEventType,
+		WitnessMissBlockEventType,
+	},
+	AdvertisingCategory: {
+		CreateBudgetEventType,
+		UpdateBudgetEventType,
+		CloseBudgetEventType,
+		CloseBudgetByAdvertisingModeratorEventType,
+		AllocateCashFromAdvertisingBudgetEventType,
+		CashBackFromAdvertisingBudgetToOwnerEventType,
+		ClosingBudgetEventType,
+	},
+}
+
+// Categories returns all categories
+func Categories() []Category {
+	return []Category{
+		SocialCategory,
+		BettingCategory,
+		NFTCategory,
+		FinanceCategory,
+		AccountCategory,
+		GovernanceCategory,
+		AdvertisingCategory,
+	}
+}
+
+// Types returns the types of the category, an unknown category has no types
+func (c Category) Types() []Type {
+	return append([]Type(nil), categoryTypes[c]...)
+}
+
+// TypesOf returns the types of all the categories without duplicates, e.g. to be passed to Provider.Provide
+func TypesOf(categories ...Category) []Type {
+	var types []Type
+	seen := make(map[Type]bool)
+	for _, c := range categories {
+		for _, t := range categoryTypes[c] {
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+
+	return types
+}
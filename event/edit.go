@@ -0,0 +1,46 @@
+package event
+
+// PostEditedEvent is produced by projection.Edits for a comment operation of a known post.
+// Body is the full new body, Patch is the diff patch the body was submitted as, empty for a full body.
+// PatchError tells why the patch could not be applied, Body is then the submitted patch as stored by the node.
+// An empty title, body or metadata in the operation keeps the previous one.
+type PostEditedEvent struct {
+	Author               string `json:"author"`
+	PermLink             string `json:"permlink"`
+	ParentPermLink       string `json:"parent_permlink"`
+	PreviousTitle        string `json:"previous_title"`
+	Title                string `json:"title"`
+	PreviousBody         string `json:"previous_body"`
+	Body                 string `json:"body"`
+	Patch                string `json:"patch"`
+	PreviousJsonMetadata string `json:"previous_json_metadata"`
+	JsonMetadata         string `json:"json_metadata"`
+	PatchError           string `json:"patch_error"`
+}
+
+func (e PostEditedEvent) Type() Type {
+	return PostEditedEventType
+}
+
+// CommentEditedEvent is produced by projection.Edits for a comment operation of a known comment.
+// Body is the full new body, Patch is the diff patch the body was submitted as, empty for a full body.
+// PatchError tells why the patch could not be applied, Body is then the submitted patch as stored by the node.
+// An empty title, body or metadata in the operation keeps the previous one.
+type CommentEditedEvent struct {
+	Author               string `json:"author"`
+	PermLink             string `json:"permlink"`
+	ParentAuthor         string `json:"parent_author"`
+	ParentPermLink       string `json:"parent_permlink"`
+	PreviousTitle        string `json:"previous_title"`
+	Title                string `json:"title"`
+	PreviousBody         string `json:"previous_body"`
+	Body                 string `json:"body"`
+	Patch                string `json:"patch"`
+	PreviousJsonMetadata string `json:"previous_json_metadata"`
+	JsonMetadata         string `json:"json_metadata"`
+	PatchError           string `json:"patch_error"`
+}
+
+func (e CommentEditedEvent) Type() Type {
+	return CommentEditedEventType
+}
@@ -0,0 +1,148 @@
+package event
+
+import (
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// toTime returns the zero time for a time absent in the operation
+func toTime(t types.Time) time.Time {
+	if t.Time == nil {
+		return time.Time{}
+	}
+
+	return *t.Time
+}
+
+// EscrowTransferEvent, the agent must approve the escrow before RatificationDeadline
+// and the funds can be released by the parties after EscrowExpiration
+type EscrowTransferEvent struct {
+	From                 string    `json:"from"`
+	To                   string    `json:"to"`
+	Agent                string    `json:"agent"`
+	EscrowID             uint32    `json:"escrow_id"`
+	Amount               Asset     `json:"amount"`
+	Fee                  Asset     `json:"fee"`
+	RatificationDeadline time.Time `json:"ratification_deadline"`
+	EscrowExpiration     time.Time `json:"escrow_expiration"`
+	JsonMetadata         string    `json:"json_metadata"`
+}
+
+func (e EscrowTransferEvent) Type() Type {
+	return EscrowTransferEventType
+}
+
+func toEscrowTransferEvent(op types.Operation) (Event, error) {
+	var v struct {
+		From                 string     `json:"from"`
+		To                   string     `json:"to"`
+		Agent                string     `json:"agent"`
+		EscrowID             uint32     `json:"escrow_id"`
+		Amount               Asset      `json:"scorum_amount"`
+		Fee                  Asset      `json:"fee"`
+		RatificationDeadline types.Time `json:"ratification_deadline"`
+		EscrowExpiration     types.Time `json:"escrow_expiration"`
+		JsonMetadata         string     `json:"json_meta"`
+	}
+	if err := unmarshalUnknownOperation(op, &v); err != nil {
+		return nil, err
+	}
+
+	return &EscrowTransferEvent{
+		From:                 v.From,
+		To:                   v.To,
+		Agent:                v.Agent,
+		EscrowID:             v.EscrowID,
+		Amount:               v.Amount,
+		Fee:                  v.Fee,
+		RatificationDeadline: toTime(v.RatificationDeadline),
+		EscrowExpiration:     toTime(v.EscrowExpiration),
+		JsonMetadata:         v.JsonMetadata,
+	}, nil
+}
+
+// EscrowApproveEvent, Who is either the receiver or the agent
+type EscrowApproveEvent struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	EscrowID uint32 `json:"escrow_id"`
+	Approve  bool   `json:"approve"`
+}
+
+func (e EscrowApproveEvent) Type() Type {
+	return EscrowApproveEventType
+}
+
+func toEscrowApproveEvent(op types.Operation) (Event, error) {
+	var e EscrowApproveEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// EscrowDisputeEvent, once disputed only the agent can release the funds
+type EscrowDisputeEvent struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	EscrowID uint32 `json:"escrow_id"`
+}
+
+func (e EscrowDisputeEvent) Type() Type {
+	return EscrowDisputeEventType
+}
+
+func toEscrowDisputeEvent(op types.Operation) (Event, error) {
+	var e EscrowDisputeEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// EscrowReleaseEvent
+type EscrowReleaseEvent struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	Receiver string `json:"receiver"`
+	EscrowID uint32 `json:"escrow_id"`
+	Amount   Asset  `json:"amount"`
+}
+
+func (e EscrowReleaseEvent) Type() Type {
+	return EscrowReleaseEventType
+}
+
+func toEscrowReleaseEvent(op types.Operation) (Event, error) {
+	var v struct {
+		From     string `json:"from"`
+		To       string `json:"to"`
+		Agent    string `json:"agent"`
+		Who      string `json:"who"`
+		Receiver string `json:"receiver"`
+		EscrowID uint32 `json:"escrow_id"`
+		Amount   Asset  `json:"scorum_amount"`
+	}
+	if err := unmarshalUnknownOperation(op, &v); err != nil {
+		return nil, err
+	}
+
+	return &EscrowReleaseEvent{
+		From:     v.From,
+		To:       v.To,
+		Agent:    v.Agent,
+		Who:      v.Who,
+		Receiver: v.Receiver,
+		EscrowID: v.EscrowID,
+		Amount:   v.Amount,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+func TestEscrowEvents(t *testing.T) {
+	scr := mustParseAsset(t, "10.000000000 SCR")
+
+	testConverters(t, []converterCase{
+		{
+			name:      "escrow transfer",
+			converter: toEscrowTransferEvent,
+			op: rawOperation(`{"from": "alice", "to": "bob", "agent": "carol", "escrow_id": 7,
+				"scorum_amount": "10.000000000 SCR", "fee": "0.100000000 SCR",
+				"ratification_deadline": "2018-08-01T10:00:00", "escrow_expiration": "2018-08-10T10:00:00", "json_meta": "{}"}`),
+			expected: &EscrowTransferEvent{
+				From:                 "alice",
+				To:                   "bob",
+				Agent:                "carol",
+				EscrowID:             7,
+				Amount:               scr,
+				Fee:                  mustParseAsset(t, "0.100000000 SCR"),
+				RatificationDeadline: time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC),
+				EscrowExpiration:     time.Date(2018, 8, 10, 10, 0, 0, 0, time.UTC),
+				JsonMetadata:         "{}",
+			},
+		},
+		{
+			name:      "escrow transfer without metadata",
+			converter: toEscrowTransferEvent,
+			op: rawOperation(`{"from": "alice", "to": "bob", "agent": "carol", "escrow_id": 7,
+				"scorum_amount": "10.000000000 SCR", "fee": "0.100000000 SCR",
+				"ratification_deadline": "2018-08-01T10:00:00", "escrow_expiration": "2018-08-10T10:00:00"}`),
+			expected: &EscrowTransferEvent{
+				From:                 "alice",
+				To:                   "bob",
+				Agent:                "carol",
+				EscrowID:             7,
+				Amount:               scr,
+				Fee:                  mustParseAsset(t, "0.100000000 SCR"),
+				RatificationDeadline: time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC),
+				EscrowExpiration:     time.Date(2018, 8, 10, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "escrow approve",
+			converter: toEscrowApproveEvent,
+			op:        rawOperation(`{"from": "alice", "to": "bob", "agent": "carol", "who": "carol", "escrow_id": 7, "approve": true}`),
+			expected:  &EscrowApproveEvent{From: "alice", To: "bob", Agent: "carol", Who: "carol", EscrowID: 7, Approve: true},
+		},
+		{
+			name:      "escrow dispute",
+			converter: toEscrowDisputeEvent,
+			op:        rawOperation(`{"from": "alice", "to": "bob", "agent": "carol", "who": "bob", "escrow_id": 7}`),
+			expected:  &EscrowDisputeEvent{From: "alice", To: "bob", Agent: "carol", Who: "bob", EscrowID: 7},
+		},
+		{
+			name:      "escrow release",
+			converter: toEscrowReleaseEvent,
+			op: rawOperation(`{"from": "alice", "to": "bob", "agent": "carol", "who": "carol", "receiver": "bob",
+				"escrow_id": 7, "scorum_amount": "10.000000000 SCR"}`),
+			expected: &EscrowReleaseEvent{From: "alice", To: "bob", Agent: "carol", Who: "carol", Receiver: "bob", EscrowID: 7, Amount: scr},
+		},
+	})
+}
+
+func TestEscrowEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "malformed deadline",
+			converter: toEscrowTransferEvent,
+			op:        rawOperation(`{"from": "alice", "ratification_deadline": "tomorrow"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "malformed escrow id",
+			converter: toEscrowApproveEvent,
+			op:        rawOperation(`{"from": "alice", "escrow_id": "seven"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "scorum-go operation",
+			converter: toEscrowReleaseEvent,
+			op:        &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
@@ -1,55 +1,118 @@
 package event
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/scorum/scorum-go/types"
 )
 
-var errWrongEventType = errors.New("wrong event type")
-
-type converter func(operation types.Operation) Event
-
-var converters map[types.OpType]converter
-
-func init() {
-	converters = map[types.OpType]converter{
-		types.AccountCreateOpType:               toAccountCreateEvent,
-		types.AccountCreateByCommitteeOpType:    toAccountCreateEvent,
-		types.AccountCreateWithDelegationOpType: toAccountCreateEvent,
-		types.VoteOpType:                        toVoteEvent,
-		types.CommentOpType:                     toCommentEvent,
-		types.DeleteCommentOpType:               toDeleteCommentEvent,
-		types.CreateGame:                        toCreateGameEvent,
-		types.CancelGame:                        toCancelGameEvent,
-		types.UpdateGameStartTime:               toUpdateGameStartTimeEvent,
-		types.PostGameResults:                   toPostGameResultsEvent,
-		types.PostBet:                           toPostBetEvent,
-		types.CancelPendingBets:                 toCancelPendingBetEvent,
-		types.BetsMatched:                       toBetsMatchedEvent,
-		types.GameStatusChanged:                 toGameStatusChangedEvent,
-		types.BetResolved:                       toBetResolvedEvent,
-		types.BetCancelled:                      toBetCancelledEvent,
-		types.TransferOpType:                    toTransferEvent,
-		types.CreateNFT:                         toCreateNFTEvent,
-		types.UpdateNFTMetadata:                 toUpdateNFTMetadataEvent,
-		types.CreateGameRound:                   toCreateGameRoundEvent,
-		types.UpdateGameRoundResult:             toUpdateGameRoundResultEvent,
-		types.BurnOperationOpType:               toBurnEvent,
-	}
+var (
+	// ErrWrongOperationType is returned by a converter given an operation of an unexpected type
+	ErrWrongOperationType = errors.New("wrong operation type")
+	// ErrMalformedOperation is returned by a converter given an operation it can not decode
+	ErrMalformedOperation = errors.New("malformed operation")
+)
+
+// builtinConverters are the converters every new Registry starts with
+var builtinConverters = map[types.OpType]Converter{
+	types.AccountCreateOpType:               toAccountCreateEvent,
+	types.AccountCreateByCommitteeOpType:    toAccountCreateEvent,
+	types.AccountCreateWithDelegationOpType: toAccountCreateEvent,
+	types.VoteOpType:                        toVoteEvent,
+	types.CommentOpType:                     toCommentEvent,
+	types.DeleteCommentOpType:               toDeleteCommentEvent,
+	types.CreateGame:                        toCreateGameEvent,
+	types.CancelGame:                        toCancelGameEvent,
+	types.UpdateGameStartTime:               toUpdateGameStartTimeEvent,
+	types.PostGameResults:                   toPostGameResultsEvent,
+	types.PostBet:                           toPostBetEvent,
+	types.CancelPendingBets:                 toCancelPendingBetEvent,
+	types.BetsMatched:                       toBetsMatchedEvent,
+	types.GameStatusChanged:                 toGameStatusChangedEvent,
+	types.BetResolved:                       toBetResolvedEvent,
+	types.BetCancelled:                      toBetCancelledEvent,
+	types.UpdateGameMarkets:                 toUpdateGameMarketsEvent,
+	types.BetRestored:                       toBetRestoredEvent,
+	types.BetUpdated:                        toBetUpdatedEvent,
+	types.TransferOpType:                    toTransferEvent,
+	types.CreateNFT:                         toCreateNFTEvent,
+	types.UpdateNFTMetadata:                 toUpdateNFTMetadataEvent,
+	types.CreateGameRound:                   toCreateGameRoundEvent,
+	types.UpdateGameRoundResult:             toUpdateGameRoundResultEvent,
+	types.AdjustNFTExperience:               toAdjustNFTExperienceEvent,
+	types.UpdateNFTName:                     toUpdateNFTNameEvent,
+	types.BurnOperationOpType:               toBurnEvent,
+	types.Hardfork:                          toHardforkEvent,
+
+	types.TransferToScorumpowerOpType:          toTransferToScorumpowerEvent,
+	types.WithdrawScorumpowerOpType:            toWithdrawScorumpowerEvent,
+	types.DelegateScorumpower:                  toDelegateScorumpowerEvent,
+	types.DelegateSPFromRegPool:                toDelegateSPFromRegPoolEvent,
+	types.SetWithdrawScorumpowerRouteToAccount: toSetWithdrawScorumpowerRouteToAccountEvent,
+	types.SetWithdrawScorumpowerRouteToDevPool: toSetWithdrawScorumpowerRouteToDevPoolEvent,
+	types.FillScorumpowerWithdraw:              toFillScorumpowerWithdrawEvent,
+	types.ReturnScorumpowerDelegation:          toReturnScorumpowerDelegationEvent,
+
+	types.WitnessUpdateOpType:       toWitnessUpdateEvent,
+	types.AccountWitnessVoteOpType:  toAccountWitnessVoteEvent,
+	types.AccountWitnessProxyOpType: toAccountWitnessProxyEvent,
+	types.ProposalCreateOperation:   toProposalCreateEvent,
+	types.ProposalVoteOperation:     toProposalVoteEvent,
+	types.ProposalVirtual:           toProposalVirtualEvent,
+	types.ShutdownWitness:           toShutdownWitnessEvent,
+	types.WitnessMissBlock:          toWitnessMissBlockEvent,
+
+	types.AccountUpdateOpType:    toAccountUpdateEvent,
+	types.RequestAccountRecovery: toRequestAccountRecoveryEvent,
+	types.RecoverAccount:         toRecoverAccountEvent,
+	types.ChangeRecoveryAccount:  toChangeRecoveryAccountEvent,
+	types.DeclineVotingRights:    toDeclineVotingRightsEvent,
+	types.ProveAuthority:         toProveAuthorityEvent,
+
+	types.EscrowTransfer:              toEscrowTransferEvent,
+	types.EscrowApprove:               toEscrowApproveEvent,
+	types.EscrowDispute:               toEscrowDisputeEvent,
+	types.EscrowRelease:               toEscrowReleaseEvent,
+	types.AtomicswapInitiateOperation: toAtomicSwapInitiateEvent,
+	types.AtomicswapRedeemOperation:   toAtomicSwapRedeemEvent,
+	types.AtomicswapRefundOperation:   toAtomicSwapRefundEvent,
+	types.ExpiredContractRefund:       toExpiredContractRefundEvent,
+
+	types.CreateBudget:                               toCreateBudgetEvent,
+	types.UpdateBudgetOperation:                      toUpdateBudgetEvent,
+	types.CloseBudget:                                toCloseBudgetEvent,
+	types.CloseBudgetByAdvertisingModeratorOperation: toCloseBudgetByAdvertisingModeratorEvent,
+	types.AllocateCashFromAdvertisingBudget:          toAllocateCashFromAdvertisingBudgetEvent,
+	types.CashBackFromAdvertisingBudgetToOwner:       toCashBackFromAdvertisingBudgetToOwnerEvent,
+	types.ClosingBudget:                              toClosingBudgetEvent,
+
+	types.CommentOptionsOpType:        toCommentOptionsEvent,
+	types.AuthorReward:                toAuthorRewardEvent,
+	types.CommentReward:               toCommentRewardEvent,
+	types.CurationReward:              toCurationRewardEvent,
+	types.CommentBenefactorReward:     toCommentBenefactorRewardEvent,
+	types.CommentPayoutUpdate:         toCommentPayoutUpdateEvent,
+	types.ActiveSpHoldersRewardLegacy: toActiveSPHoldersRewardLegacyEvent,
 }
 
 type Event interface {
 	Type() Type
 }
 
-func ToEvent(op types.Operation) Event {
-	if converter, exists := converters[op.Type()]; exists {
-		return converter(op)
-	}
+// BlockTimed is an event with values computed from the time of its block, e.g. a deadline.
+// The provider calls SetBlockTime with the block time after converting the operation.
+type BlockTimed interface {
+	Event
+	SetBlockTime(t time.Time)
+}
 
-	return UnknownEvent{}
+// ToEvent converts the operation with the DefaultRegistry
+func ToEvent(op types.Operation) (Event, error) {
+	return DefaultRegistry.ToEvent(op)
 }
 
 type Block struct {
@@ -60,14 +123,14 @@ type Block struct {
 
 // AccountCreateEvent
 type AccountCreateEvent struct {
-	Account string
+	Account string `json:"account"`
 }
 
 func (e AccountCreateEvent) Type() Type {
 	return AccountCreateEventType
 }
 
-func toAccountCreateEvent(op types.Operation) Event {
+func toAccountCreateEvent(op types.Operation) (Event, error) {
 	account := ""
 	switch v := op.(type) {
 	case *types.AccountCreateOperation:
@@ -77,30 +140,30 @@ func toAccountCreateEvent(op types.Operation) Event {
 	case *types.AccountCreateWithDelegationOperation:
 		account = v.NewAccountName
 	default:
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
 	return &AccountCreateEvent{
 		Account: account,
-	}
+	}, nil
 }
 
 // VoteEvent
 type VoteEvent struct {
-	Voter    string
-	Author   string
-	PermLink string
-	Weight   int16
+	Voter    string `json:"voter"`
+	Author   string `json:"author"`
+	PermLink string `json:"permlink"`
+	Weight   int16  `json:"weight"`
 }
 
 func (e VoteEvent) Type() Type {
 	return VoteEventType
 }
 
-func toVoteEvent(op types.Operation) Event {
+func toVoteEvent(op types.Operation) (Event, error) {
 	v, ok := op.(*types.VoteOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
 	if v.Weight < 0 {
@@ -109,23 +172,23 @@ func toVoteEvent(op types.Operation) Event {
 			Author:   v.Author,
 			PermLink: v.Permlink,
 			Weight:   v.Weight,
-		}
+		}, nil
 	} else {
 		return &VoteEvent{
 			Voter:    v.Voter,
 			Author:   v.Author,
 			PermLink: v.Permlink,
 			Weight:   v.Weight,
-		}
+		}, nil
 	}
 }
 
 // FlagsEvent
 type FlagEvent struct {
-	Voter    string
-	Author   string
-	PermLink string
-	Weight   int16
+	Voter    string `json:"voter"`
+	Author   string `json:"author"`
+	PermLink string `json:"permlink"`
+	Weight   int16  `json:"weight"`
 }
 
 func (e FlagEvent) Type() Type {
@@ -134,13 +197,13 @@ func (e FlagEvent) Type() Type {
 
 // CommentEvent
 type CommentEvent struct {
-	PermLink       string
-	ParentAuthor   string
-	ParentPermLink string
-	Author         string
-	Body           string
-	JsonMetadata   string
-	Title          string
+	PermLink       string `json:"permlink"`
+	ParentAuthor   string `json:"parent_author"`
+	ParentPermLink string `json:"parent_permlink"`
+	Author         string `json:"author"`
+	Body           string `json:"body"`
+	JsonMetadata   string `json:"json_metadata"`
+	Title          string `json:"title"`
 }
 
 func (e CommentEvent) Type() Type {
@@ -149,22 +212,22 @@ func (e CommentEvent) Type() Type {
 
 // PostEvent
 type PostEvent struct {
-	PermLink       string
-	ParentPermLink string
-	Author         string
-	Body           string
-	JsonMetadata   string
-	Title          string
+	PermLink       string `json:"permlink"`
+	ParentPermLink string `json:"parent_permlink"`
+	Author         string `json:"author"`
+	Body           string `json:"body"`
+	JsonMetadata   string `json:"json_metadata"`
+	Title          string `json:"title"`
 }
 
 func (e PostEvent) Type() Type {
 	return PostEventType
 }
 
-func toCommentEvent(op types.Operation) Event {
+func toCommentEvent(op types.Operation) (Event, error) {
 	v, ok := op.(*types.CommentOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
 	if v.ParentAuthor == "" {
@@ -175,7 +238,7 @@ func toCommentEvent(op types.Operation) Event {
 			Body:           v.Body,
 			JsonMetadata:   v.JsonMetadata,
 			Title:          v.Title,
-		}
+		}, nil
 	} else {
 		return &CommentEvent{
 			PermLink:       v.Permlink,
@@ -185,30 +248,30 @@ func toCommentEvent(op types.Operation) Event {
 			Body:           v.Body,
 			JsonMetadata:   v.JsonMetadata,
 			Title:          v.Title,
-		}
+		}, nil
 	}
 }
 
 // DeleteComment
 type DeleteCommentEvent struct {
-	PermLink string
-	Author   string
+	PermLink string `json:"permlink"`
+	Author   string `json:"author"`
 }
 
 func (e DeleteCommentEvent) Type() Type {
 	return DeleteCommentEventType
 }
 
-func toDeleteCommentEvent(op types.Operation) Event {
+func toDeleteCommentEvent(op types.Operation) (Event, error) {
 	v, ok := op.(*types.DeleteCommentOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
 	return &DeleteCommentEvent{
 		PermLink: v.Permlink,
 		Author:   v.Author,
-	}
+	}, nil
 }
 
 type CreateGameEvent struct {
@@ -219,13 +282,13 @@ func (e CreateGameEvent) Type() Type {
 	return CreateGameEventType
 }
 
-func toCreateGameEvent(op types.Operation) Event {
+func toCreateGameEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.CreateGameOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return CreateGameEvent{*e}
+	return CreateGameEvent{*e}, nil
 }
 
 type CancelGameEvent struct {
@@ -236,13 +299,13 @@ func (e CancelGameEvent) Type() Type {
 	return CancelGameEventType
 }
 
-func toCancelGameEvent(op types.Operation) Event {
+func toCancelGameEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.CancelGameOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return CancelGameEvent{*e}
+	return CancelGameEvent{*e}, nil
 }
 
 type UpdateGameStartTimeEvent struct {
@@ -253,13 +316,13 @@ func (e UpdateGameStartTimeEvent) Type() Type {
 	return UpdateGameStartEventType
 }
 
-func toUpdateGameStartTimeEvent(op types.Operation) Event {
+func toUpdateGameStartTimeEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.UpdateGameStartTimeOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return UpdateGameStartTimeEvent{*e}
+	return UpdateGameStartTimeEvent{*e}, nil
 }
 
 type PostGameResultsEvent struct {
@@ -270,13 +333,13 @@ func (e PostGameResultsEvent) Type() Type {
 	return PostGameResultsEventType
 }
 
-func toPostGameResultsEvent(op types.Operation) Event {
+func toPostGameResultsEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.PostGameResultsOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return PostGameResultsEvent{*e}
+	return PostGameResultsEvent{*e}, nil
 }
 
 type PostBetEvent struct {
@@ -287,13 +350,13 @@ func (e PostBetEvent) Type() Type {
 	return PostBetEventType
 }
 
-func toPostBetEvent(op types.Operation) Event {
+func toPostBetEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.PostBetOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return PostBetEvent{*e}
+	return PostBetEvent{*e}, nil
 }
 
 type CancelPendingBetEvent struct {
@@ -304,13 +367,13 @@ func (e CancelPendingBetEvent) Type() Type {
 	return CancelPendingBetsEventType
 }
 
-func toCancelPendingBetEvent(op types.Operation) Event {
+func toCancelPendingBetEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.CancelPendingBetsOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return CancelPendingBetEvent{*e}
+	return CancelPendingBetEvent{*e}, nil
 }
 
 type BetsMatchedEvent struct {
@@ -321,13 +384,13 @@ func (e BetsMatchedEvent) Type() Type {
 	return BetsMatchedEventType
 }
 
-func toBetsMatchedEvent(op types.Operation) Event {
+func toBetsMatchedEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.BetsMatchedVirtualOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return BetsMatchedEvent{*e}
+	return BetsMatchedEvent{*e}, nil
 }
 
 type GameStatusChangedEvent struct {
@@ -338,13 +401,13 @@ func (e GameStatusChangedEvent) Type() Type {
 	return GameStatusChangedEventType
 }
 
-func toGameStatusChangedEvent(op types.Operation) Event {
+func toGameStatusChangedEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.GameStatusChangedVirtualOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return GameStatusChangedEvent{*e}
+	return GameStatusChangedEvent{*e}, nil
 }
 
 type BetResolvedEvent struct {
@@ -355,13 +418,13 @@ func (e BetResolvedEvent) Type() Type {
 	return BetResolvedEventType
 }
 
-func toBetResolvedEvent(op types.Operation) Event {
+func toBetResolvedEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.BetResolvedOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return BetResolvedEvent{*e}
+	return BetResolvedEvent{*e}, nil
 }
 
 type BetCancelledEvent struct {
@@ -372,13 +435,78 @@ func (e BetCancelledEvent) Type() Type {
 	return BetCancelledEventType
 }
 
-func toBetCancelledEvent(op types.Operation) Event {
+func toBetCancelledEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.BetCancelledOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
+	}
+
+	return BetCancelledEvent{*e}, nil
+}
+
+// UpdateGameMarketsEvent, Markets is the complete list of the game markets after the update
+type UpdateGameMarketsEvent struct {
+	UUID      uuid.UUID      `json:"uuid"`
+	Moderator string         `json:"moderator"`
+	Markets   []types.Market `json:"markets"`
+}
+
+func (e UpdateGameMarketsEvent) Type() Type {
+	return UpdateGameMarketsEventType
+}
+
+func toUpdateGameMarketsEvent(op types.Operation) (Event, error) {
+	var e UpdateGameMarketsEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// BetRestoredEvent is produced by the bet_restored virtual operation when a bet
+// cancelled by a game start time change is restored
+type BetRestoredEvent struct {
+	GameUUID uuid.UUID   `json:"game_uuid"`
+	Better   string      `json:"better"`
+	BetUUID  uuid.UUID   `json:"bet_uuid"`
+	Stake    types.Asset `json:"stake"`
+}
+
+func (e BetRestoredEvent) Type() Type {
+	return BetRestoredEventType
+}
+
+func toBetRestoredEvent(op types.Operation) (Event, error) {
+	var e BetRestoredEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// BetUpdatedEvent is produced by the bet_updated virtual operation when the stake of a bet is changed
+type BetUpdatedEvent struct {
+	GameUUID uuid.UUID           `json:"game_uuid"`
+	Better   string              `json:"better"`
+	BetUUID  uuid.UUID           `json:"bet_uuid"`
+	Kind     types.BetCancelKind `json:"kind"`
+	OldStake types.Asset         `json:"old_stake"`
+	NewStake types.Asset         `json:"new_stake"`
+}
+
+func (e BetUpdatedEvent) Type() Type {
+	return BetUpdatedEventType
+}
+
+func toBetUpdatedEvent(op types.Operation) (Event, error) {
+	var e BetUpdatedEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
 	}
 
-	return BetCancelledEvent{*e}
+	return e, nil
 }
 
 type TransferEvent struct {
@@ -389,102 +517,137 @@ func (e TransferEvent) Type() Type {
 	return TransferEventType
 }
 
-func toTransferEvent(op types.Operation) Event {
+func toTransferEvent(op types.Operation) (Event, error) {
 	e, ok := op.(*types.TransferOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return TransferEvent{*e}
+	return TransferEvent{*e}, nil
 }
 
-type CreateNFTEvent struct {
-	types.CreateNFTOperation
+type BurnEvent struct {
+	types.BurnOperation
 }
 
-func (e CreateNFTEvent) Type() Type {
-	return CreateGameEventType
+func (e BurnEvent) Type() Type {
+	return BurnEventType
 }
 
-func toCreateNFTEvent(op types.Operation) Event {
-	e, ok := op.(*types.CreateNFTOperation)
+func toBurnEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.BurnOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return nil, ErrWrongOperationType
 	}
 
-	return CreateNFTEvent{*e}
+	return BurnEvent{*e}, nil
 }
 
-type UpdateNFTMetadataEvent struct {
-	types.UpdateNFTMetadataOperation
+// HardforkEvent is produced by the hardfork virtual operation in the block the hardfork was applied
+type HardforkEvent struct {
+	HardforkID uint32 `json:"hardfork_id"`
 }
 
-func (e UpdateNFTMetadataEvent) Type() Type {
-	return UpdateNFTMetadataEventType
+func (e HardforkEvent) Type() Type {
+	return HardforkEventType
 }
 
-func toUpdateNFTMetadataEvent(op types.Operation) Event {
-	e, ok := op.(*types.UpdateNFTMetadataOperation)
-	if !ok {
-		panic(errWrongEventType)
+func toHardforkEvent(op types.Operation) (Event, error) {
+	var e HardforkEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
 	}
 
-	return UpdateNFTMetadataEvent{*e}
+	return e, nil
 }
 
-type CreateGameRoundEvent struct {
-	types.CreateGameRoundOperation
+// HardforkVersionChangedEvent is produced by the provider when ChainProperties.HFVersion changes.
+// The node only exposes the current properties, a change is provided with the head block of the poll it is observed at,
+// which can be later than the block it happened in; HardforkEvent marks the block a hardfork was applied in.
+// A change made before the first poll is only provided when the provider is resumed from a Checkpoint keeping the previous state.
+type HardforkVersionChangedEvent struct {
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
 }
 
-func (e CreateGameRoundEvent) Type() Type {
-	return UpdateNFTMetadataEventType
+func (e HardforkVersionChangedEvent) Type() Type {
+	return HardforkVersionChangedEventType
 }
 
-func toCreateGameRoundEvent(op types.Operation) Event {
-	e, ok := op.(*types.CreateGameRoundOperation)
-	if !ok {
-		panic(errWrongEventType)
-	}
-
-	return CreateGameRoundEvent{*e}
+// MajorityVersionChangedEvent is produced by the provider when ChainProperties.MajorityVersion changes.
+// Like HardforkVersionChangedEvent, it is provided with the head block of the poll the change is observed at.
+type MajorityVersionChangedEvent struct {
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
 }
 
-type UpdateGameRoundResultEvent struct {
-	types.UpdateGameRoundResultOperation
+func (e MajorityVersionChangedEvent) Type() Type {
+	return MajorityVersionChangedEventType
 }
 
-func (e UpdateGameRoundResultEvent) Type() Type {
-	return UpdateNFTMetadataEventType
+// ChainProperties are the median chain properties voted by the witnesses
+type ChainProperties struct {
+	AccountCreationFee types.Asset `json:"account_creation_fee"`
+	MaximumBlockSize   uint32      `json:"maximum_block_size"`
 }
 
-func toUpdateGameRoundResultEvent(op types.Operation) Event {
-	e, ok := op.(*types.UpdateGameRoundResultOperation)
-	if !ok {
-		panic(errWrongEventType)
-	}
-
-	return UpdateGameRoundResultEvent{*e}
+// Equals returns whether both properties have the same values
+func (p ChainProperties) Equals(p2 ChainProperties) bool {
+	return p.MaximumBlockSize == p2.MaximumBlockSize &&
+		p.AccountCreationFee.Decimal().Equal(p2.AccountCreationFee.Decimal())
 }
 
-type BurnEvent struct {
-	types.BurnOperation
+// ChainPropertiesChangedEvent is produced by the provider when the median chain properties change.
+// Like HardforkVersionChangedEvent, it is provided with the head block of the poll the change is observed at.
+type ChainPropertiesChangedEvent struct {
+	Old ChainProperties `json:"old"`
+	New ChainProperties `json:"new"`
 }
 
-func (e BurnEvent) Type() Type {
-	return BurnEventType
+func (e ChainPropertiesChangedEvent) Type() Type {
+	return ChainPropertiesChangedEventType
 }
 
-func toBurnEvent(op types.Operation) Event {
-	e, ok := op.(*types.BurnOperation)
+// unmarshalUnknownOperation decodes the raw data of an operation unknown to scorum-go
+func unmarshalUnknownOperation(op types.Operation, v interface{}) error {
+	u, ok := op.(*types.UnknownOperation)
 	if !ok {
-		panic(errWrongEventType)
+		return ErrWrongOperationType
 	}
 
-	return BurnEvent{*e}
+	if err := json.Unmarshal(u.Data, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrMalformedOperation, err)
+	}
+
+	return nil
 }
 
-type UnknownEvent struct{}
+// UnknownEvent keeps an operation without a converter, e.g. one added by a hardfork
+type UnknownEvent struct {
+	OpType types.OpType `json:"op_type"`
+	// Data is the raw JSON payload of the operation
+	Data json.RawMessage `json:"data"`
+}
 
 func (e UnknownEvent) Type() Type {
 	return UnknownEventType
 }
+
+// Decode unmarshals the payload of the operation into v
+func (e UnknownEvent) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+func toUnknownEvent(op types.Operation) (Event, error) {
+	if u, ok := op.(*types.UnknownOperation); ok {
+		return UnknownEvent{OpType: u.Type(), Data: u.Data}, nil
+	}
+
+	// the operation is decoded by scorum-go, but has no converter
+	data, err := json.Marshal(op)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedOperation, err)
+	}
+
+	return UnknownEvent{OpType: op.Type(), Data: data}, nil
+}
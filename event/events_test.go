@@ -0,0 +1,219 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConverters_MalformedOperations(t *testing.T) {
+	malformed := map[string]types.Operation{
+		"nil":                   nil,
+		"wrong operation":       &types.ProducerRewardOperation{Producer: "alice"},
+		"unknown operation":     &types.UnknownOperation{Data: json.RawMessage(`{}`)},
+		"invalid json":          &types.UnknownOperation{Data: json.RawMessage(`{`)},
+		"unexpected json":       &types.UnknownOperation{Data: json.RawMessage(`[1, 2]`)},
+		"unexpected field type": &types.UnknownOperation{Data: json.RawMessage(`{"hardfork_id": "one"}`)},
+	}
+
+	for opType, converter := range builtinConverters {
+		for name, op := range malformed {
+			t.Run(string(opType)+"/"+name, func(t *testing.T) {
+				require.NotPanics(t, func() {
+					ev, err := converter(op)
+					if err == nil {
+						// operations decoded from raw data tolerate missing fields
+						require.IsType(t, &types.UnknownOperation{}, op)
+						require.NotNil(t, ev)
+						return
+					}
+
+					require.Nil(t, ev)
+					require.True(t, errors.Is(err, ErrWrongOperationType) || errors.Is(err, ErrMalformedOperation), err)
+				})
+			})
+		}
+	}
+}
+
+// decodeOperation decodes the operation like the node API does, unknown operations are kept raw
+func decodeOperation(t *testing.T, opType types.OpType, data string) types.Operation {
+	var ops types.OperationsFlat
+	require.NoError(t, json.Unmarshal([]byte(`["`+string(opType)+`", `+data+`]`), &ops))
+	require.Len(t, ops, 1)
+	return ops[0]
+}
+
+func TestConverters_Types(t *testing.T) {
+	// accounts can be created by several operations
+	shared := map[Type]bool{AccountCreateEventType: true}
+
+	cases := map[types.OpType]struct {
+		data     string
+		expected Type
+	}{
+		types.AccountCreateOpType:               {expected: AccountCreateEventType},
+		types.AccountCreateByCommitteeOpType:    {expected: AccountCreateEventType},
+		types.AccountCreateWithDelegationOpType: {expected: AccountCreateEventType},
+		types.VoteOpType:                        {expected: VoteEventType},
+		types.CommentOpType:                     {expected: PostEventType},
+		types.DeleteCommentOpType:               {expected: DeleteCommentEventType},
+		types.CreateGame:                        {expected: CreateGameEventType},
+		types.CancelGame:                        {expected: CancelGameEventType},
+		types.UpdateGameStartTime:               {expected: UpdateGameStartEventType},
+		types.PostGameResults:                   {expected: PostGameResultsEventType},
+		types.PostBet:                           {expected: PostBetEventType},
+		types.CancelPendingBets:                 {expected: CancelPendingBetsEventType},
+		types.BetsMatched:                       {expected: BetsMatchedEventType},
+		types.GameStatusChanged:                 {expected: GameStatusChangedEventType},
+		types.BetResolved:                       {expected: BetResolvedEventType},
+		types.BetCancelled:                      {expected: BetCancelledEventType},
+		types.UpdateGameMarkets:                 {expected: UpdateGameMarketsEventType},
+		types.BetRestored:                       {expected: BetRestoredEventType},
+		types.BetUpdated:                        {expected: BetUpdatedEventType},
+		types.TransferOpType:                    {expected: TransferEventType},
+		types.CreateNFT:                         {expected: CreateNFTEventType},
+		types.UpdateNFTMetadata:                 {expected: UpdateNFTMetadataEventType},
+		types.CreateGameRound:                   {expected: CreateGameRoundEventType},
+		types.UpdateGameRoundResult:             {expected: UpdateGameRoundResultEventType},
+		types.AdjustNFTExperience:               {expected: AdjustNFTExperienceEventType},
+		types.UpdateNFTName:                     {expected: UpdateNFTNameEventType},
+		types.BurnOperationOpType:               {expected: BurnEventType},
+		types.Hardfork:                          {expected: HardforkEventType},
+
+		types.TransferToScorumpowerOpType:          {data: `{"amount": "1.000000000 SCR"}`, expected: TransferToScorumpowerEventType},
+		types.WithdrawScorumpowerOpType:            {data: `{"scorumpower": "1.000000000 SP"}`, expected: WithdrawScorumpowerEventType},
+		types.DelegateScorumpower:                  {data: `{"scorumpower": "1.000000000 SP"}`, expected: DelegateScorumpowerEventType},
+		types.DelegateSPFromRegPool:                {data: `{"scorumpower": "1.000000000 SP"}`, expected: DelegateSPFromRegPoolEventType},
+		types.SetWithdrawScorumpowerRouteToAccount: {expected: SetWithdrawScorumpowerRouteToAccountEventType},
+		types.SetWithdrawScorumpowerRouteToDevPool: {expected: SetWithdrawScorumpowerRouteToDevPoolEventType},
+		types.FillScorumpowerWithdraw:              {expected: FillScorumpowerWithdrawEventType},
+		types.ReturnScorumpowerDelegation:          {expected: ReturnScorumpowerDelegationEventType},
+
+		types.WitnessUpdateOpType:       {data: `{"props": {"account_creation_fee": "1.000000000 SCR"}}`, expected: WitnessUpdateEventType},
+		types.AccountWitnessVoteOpType:  {expected: AccountWitnessVoteEventType},
+		types.AccountWitnessProxyOpType: {expected: AccountWitnessProxyEventType},
+		types.ProposalCreateOperation:   {expected: ProposalCreateEventType},
+		types.ProposalVoteOperation:     {expected: ProposalVoteEventType},
+		types.ProposalVirtual:           {expected: ProposalVirtualEventType},
+		types.ShutdownWitness:           {expected: ShutdownWitnessEventType},
+		types.WitnessMissBlock:          {expected: WitnessMissBlockEventType},
+
+		types.AccountUpdateOpType:    {expected: AccountUpdateEventType},
+		types.RequestAccountRecovery: {expected: RequestAccountRecoveryEventType},
+		types.RecoverAccount:         {expected: RecoverAccountEventType},
+		types.ChangeRecoveryAccount:  {expected: ChangeRecoveryAccountEventType},
+		types.DeclineVotingRights:    {expected: DeclineVotingRightsEventType},
+		types.ProveAuthority:         {expected: ProveAuthorityEventType},
+
+		types.EscrowTransfer:              {expected: EscrowTransferEventType},
+		types.EscrowApprove:               {expected: EscrowApproveEventType},
+		types.EscrowDispute:               {expected: EscrowDisputeEventType},
+		types.EscrowRelease:               {expected: EscrowReleaseEventType},
+		types.AtomicswapInitiateOperation: {expected: AtomicSwapInitiateEventType},
+		types.AtomicswapRedeemOperation:   {expected: AtomicSwapRedeemEventType},
+		types.AtomicswapRefundOperation:   {expected: AtomicSwapRefundEventType},
+		types.ExpiredContractRefund:       {expected: ExpiredContractRefundEventType},
+
+		types.CreateBudget:                               {expected: CreateBudgetEventType},
+		types.UpdateBudgetOperation:                      {expected: UpdateBudgetEventType},
+		types.CloseBudget:                                {expected: CloseBudgetEventType},
+		types.CloseBudgetByAdvertisingModeratorOperation: {expected: CloseBudgetByAdvertisingModeratorEventType},
+		types.AllocateCashFromAdvertisingBudget:          {expected: AllocateCashFromAdvertisingBudgetEventType},
+		types.CashBackFromAdvertisingBudgetToOwner:       {expected: CashBackFromAdvertisingBudgetToOwnerEventType},
+		types.ClosingBudget:                              {expected: ClosingBudgetEventType},
+
+		types.CommentOptionsOpType:        {data: `{"max_accepted_payout": "1.000000000 SP"}`, expected: CommentOptionsEventType},
+		types.AuthorReward:                {expected: AuthorRewardEventType},
+		types.CommentReward:               {expected: CommentRewardEventType},
+		types.CurationReward:              {expected: CurationRewardEventType},
+		types.CommentBenefactorReward:     {expected: CommentBenefactorRewardEventType},
+		types.CommentPayoutUpdate:         {expected: CommentPayoutUpdateEventType},
+		types.ActiveSpHoldersRewardLegacy: {expected: ActiveSPHoldersRewardLegacyEventType},
+	}
+
+	producers := make(map[Type]types.OpType)
+	for opType, converter := range builtinConverters {
+		c, ok := cases[opType]
+		require.True(t, ok, "no case for %s", opType)
+
+		data := c.data
+		if data == "" {
+			data = "{}"
+		}
+
+		ev, err := converter(decodeOperation(t, opType, data))
+		require.NoError(t, err, opType)
+		require.Equal(t, c.expected, ev.Type(), opType)
+
+		if producer, exists := producers[ev.Type()]; exists && !shared[ev.Type()] {
+			t.Errorf("%s and %s are converted into events of the same type %d", producer, opType, ev.Type())
+		}
+		producers[ev.Type()] = opType
+	}
+}
+
+func TestToEvent(t *testing.T) {
+	ev, err := ToEvent(&types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+	require.NoError(t, err)
+	require.Equal(t, &VoteEvent{Voter: "alice", Author: "bob", PermLink: "post", Weight: 100}, ev)
+
+	_, err = ToEvent(nil)
+	require.True(t, errors.Is(err, ErrWrongOperationType))
+}
+
+func TestToEvent_Unknown(t *testing.T) {
+	ev, err := ToEvent(&types.ProducerRewardOperation{Producer: "alice", Scorumpower: "1.000000000 SP"})
+	require.NoError(t, err)
+	require.IsType(t, UnknownEvent{}, ev)
+
+	unknown := ev.(UnknownEvent)
+	require.Equal(t, types.ProducerRewardOpType, unknown.OpType)
+	require.JSONEq(t, `{"producer": "alice", "reward": "1.000000000 SP"}`, string(unknown.Data))
+
+	var op types.ProducerRewardOperation
+	require.NoError(t, unknown.Decode(&op))
+	require.Equal(t, "alice", op.Producer)
+}
+
+func TestBettingEvents(t *testing.T) {
+	gameUUID := uuid.MustParse("e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f")
+	betUUID := uuid.MustParse("a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e")
+
+	ev, err := toUpdateGameMarketsEvent(rawOperation(`{"uuid": "` + gameUUID.String() + `", "moderator": "alice",
+		"markets": [["result_home", {}], ["total", {"threshold": 2500}]]}`))
+	require.NoError(t, err)
+
+	markets := ev.(UpdateGameMarketsEvent)
+	require.Equal(t, gameUUID, markets.UUID)
+	require.Equal(t, "alice", markets.Moderator)
+	require.Len(t, markets.Markets, 2)
+	require.Equal(t, "result_home", markets.Markets[0].GetName())
+	require.Equal(t, &types.OverUnderMarket{ID: types.MarketTotal, Threshold: 2500}, markets.Markets[1].MarketInterface)
+
+	ev, err = toBetRestoredEvent(rawOperation(`{"game_uuid": "` + gameUUID.String() + `", "better": "bob",
+		"bet_uuid": "` + betUUID.String() + `", "stake": "1.000000000 SCR"}`))
+	require.NoError(t, err)
+
+	restored := ev.(BetRestoredEvent)
+	require.Equal(t, gameUUID, restored.GameUUID)
+	require.Equal(t, betUUID, restored.BetUUID)
+	require.Equal(t, "bob", restored.Better)
+	require.Equal(t, "1.000000000 SCR", restored.Stake.String())
+
+	ev, err = toBetUpdatedEvent(rawOperation(`{"game_uuid": "` + gameUUID.String() + `", "better": "bob",
+		"bet_uuid": "` + betUUID.String() + `", "kind": "matched", "old_stake": "1.000000000 SCR", "new_stake": "0.500000000 SCR"}`))
+	require.NoError(t, err)
+
+	updated := ev.(BetUpdatedEvent)
+	require.Equal(t, types.MatchedBetKind, updated.Kind)
+	require.Equal(t, "1.000000000 SCR", updated.OldStake.String())
+	require.Equal(t, "0.500000000 SCR", updated.NewStake.String())
+
+	_, err = toUpdateGameMarketsEvent(rawOperation(`{"markets": [["no_such_market", {}]]}`))
+	require.ErrorIs(t, err, ErrMalformedOperation)
+}
@@ -0,0 +1,150 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON envelope written by MarshalEvent.
+//
+// Within a schema version the type names and the payload field names never change,
+// new event types and new payload fields may be added. Decoders ignore unknown payload fields.
+const SchemaVersion = 1
+
+var (
+	// ErrUnsupportedSchemaVersion is returned for an envelope written by a newer schema version
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+)
+
+// envelope is the JSON form of an event, e.g.
+//
+//	{"type": "vote", "version": 1, "payload": {"voter": "alice", "author": "bob", "permlink": "post", "weight": 10000}}
+type envelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// MarshalEvent encodes the event in the versioned JSON envelope
+func MarshalEvent(e Event) ([]byte, error) {
+	if e == nil {
+		return nil, errors.New("can't marshal nil event")
+	}
+
+	eventTypes.RLock()
+	info, exists := eventTypes.byType[e.Type()]
+	eventTypes.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownEventType, e.Type())
+	}
+
+	// scorum-go types implement the json interfaces on pointers, the event is encoded through a pointer so they are used
+	v := reflect.ValueOf(e)
+	if v.Kind() != reflect.Ptr {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+
+	payload, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal %s event: %w", info.name, err)
+	}
+
+	return json.Marshal(envelope{
+		Type:    info.name,
+		Version: SchemaVersion,
+		Payload: payload,
+	})
+}
+
+// UnmarshalEvent decodes an event encoded by MarshalEvent
+func UnmarshalEvent(data []byte) (Event, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+
+	if env.Version < 1 || env.Version > SchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, env.Version)
+	}
+
+	eventType, err := ParseType(env.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewEvent(eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(e)
+	if v.Kind() != reflect.Ptr {
+		v = reflect.New(v.Type())
+	}
+
+	if err := json.Unmarshal(env.Payload, v.Interface()); err != nil {
+		return nil, fmt.Errorf("can't unmarshal %s event: %w", env.Type, err)
+	}
+
+	if reflect.TypeOf(e).Kind() != reflect.Ptr {
+		v = v.Elem()
+	}
+
+	return v.Interface().(Event), nil
+}
+
+type jsonBlock struct {
+	BlockNum  uint32            `json:"block_num"`
+	Timestamp time.Time         `json:"timestamp"`
+	Events    []json.RawMessage `json:"events"`
+}
+
+// MarshalJSON encodes the block with its events in the versioned JSON envelope
+func (b Block) MarshalJSON() ([]byte, error) {
+	block := jsonBlock{
+		BlockNum:  b.BlockNum,
+		Timestamp: b.Timestamp,
+		Events:    make([]json.RawMessage, 0, len(b.Events)),
+	}
+
+	for _, e := range b.Events {
+		data, err := MarshalEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		block.Events = append(block.Events, data)
+	}
+
+	return json.Marshal(block)
+}
+
+// UnmarshalJSON decodes a block encoded by MarshalJSON
+func (b *Block) UnmarshalJSON(data []byte) error {
+	var block jsonBlock
+	if err := json.Unmarshal(data, &block); err != nil {
+		return err
+	}
+
+	var events []Event
+	for _, data := range block.Events {
+		e, err := UnmarshalEvent(data)
+		if err != nil {
+			return err
+		}
+		events = append(events, e)
+	}
+
+	*b = Block{
+		BlockNum:  block.BlockNum,
+		Timestamp: block.Timestamp,
+		Events:    events,
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	game = `"uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f", "moderator": "alice"`
+	bet  = `"game_uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f", "better": "bob", "bet_uuid": "a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e"`
+	nft  = `"uuid": "4a1d7c3e-8f5b-4c2a-9e6d-1b3f5a7c9e0d"`
+)
+
+// sampleOperations are operations with every field set, one for every built-in event type produced by a converter.
+// Raw JSON like ProposalOperation.Data is compact, encoding/json compacts json.RawMessage.
+var sampleOperations = []struct {
+	opType types.OpType
+	data   string
+}{
+	{types.AccountCreateByCommitteeOpType, `{"creator": "alice", "new_account_name": "bob"}`},
+	{types.CommentOpType, `{"parent_author": "", "parent_permlink": "football", "author": "alice", "permlink": "post", "title": "Title", "body": "Body", "json_metadata": "{\"tags\": [\"football\"]}"}`},
+	{types.CommentOpType, `{"parent_author": "alice", "parent_permlink": "post", "author": "bob", "permlink": "comment", "title": "", "body": "Body", "json_metadata": "{}"}`},
+	{types.VoteOpType, `{"voter": "bob", "author": "alice", "permlink": "post", "weight": 10000}`},
+	{types.VoteOpType, `{"voter": "bob", "author": "alice", "permlink": "post", "weight": -10000}`},
+	{types.DeleteCommentOpType, `{"author": "alice", "permlink": "post"}`},
+	{types.CreateGame, `{` + game + `, "json_metadata": "{}", "game": ["soccer_game", {}], "start_time": "2019-06-01T18:00:00",
+		"auto_resolve_delay_sec": 86400, "markets": [["result_home", {}], ["total", {"threshold": 2500}], ["correct_score", {"home": 1, "away": 0}]]}`},
+	{types.CancelGame, `{` + game + `}`},
+	{types.UpdateGameStartTime, `{` + game + `, "start_time": "2019-06-01T19:00:00"}`},
+	{types.PostGameResults, `{` + game + `, "wincases": [["result_home::yes", {}], ["total::over", {"threshold": 2500}]]}`},
+	{types.PostBet, `{"uuid": "a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e", "better": "bob", "game_uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f",
+		"wincase": ["correct_score::yes", {"home": 1, "away": 0}], "odds": {"numerator": 3, "denominator": 2}, "stake": "1.000000000 SCR", "live": true}`},
+	{types.CancelPendingBets, `{"bet_uuids": ["a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e"], "better": "bob"}`},
+	{types.BetsMatched, `{"bet1_uuid": "a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e", "bet2_uuid": "b3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e",
+		"better1": "bob", "better2": "carol", "matched_stake1": "1.000000000 SCR", "matched_stake2": "0.500000000 SCR", "matched_bet_id": 1}`},
+	{types.GameStatusChanged, `{"game_uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f", "old_status": "created", "new_status": "started"}`},
+	{types.BetResolved, `{` + bet + `, "income": "1.500000000 SCR", "kind": "win"}`},
+	{types.BetCancelled, `{` + bet + `, "stake": "1.000000000 SCR", "kind": "pending"}`},
+	{types.TransferOpType, `{"from": "alice", "to": "bob", "amount": "1.000000000 SCR", "memo": "memo"}`},
+	{types.CreateNFT, `{"owner": "alice", ` + nft + `, "name": "car", "json_metadata": "{}", "initial_power": 100}`},
+	{types.UpdateNFTMetadata, `{"moderator": "alice", ` + nft + `, "json_metadata": "{\"color\": \"red\"}"}`},
+	{types.BurnOperationOpType, `{"owner": "alice", "to": "null", "amount": "1.000000000 SCR"}`},
+	{types.Hardfork, `{"hardfork_id": 3}`},
+
+	{types.TransferToScorumpowerOpType, `{"from": "alice", "to": "bob", "amount": "1.000000000 SCR"}`},
+	{types.WithdrawScorumpowerOpType, `{"account": "alice", "scorumpower": "1.000000000 SP"}`},
+	{types.DelegateScorumpower, `{"delegator": "alice", "delegatee": "bob", "scorumpower": "1.000000000 SP"}`},
+	{types.DelegateSPFromRegPool, `{"reg_committee_member": "alice", "delegatee": "bob", "scorumpower": "1.000000000 SP"}`},
+	{types.SetWithdrawScorumpowerRouteToAccount, `{"from_account": "alice", "to_account": "bob", "percent": 5000, "auto_vest": true}`},
+	{types.SetWithdrawScorumpowerRouteToDevPool, `{"from_account": "alice", "percent": 5000, "auto_vest": true}`},
+	{types.FillScorumpowerWithdraw, `{"from_account": "alice", "to_account": "bob", "withdrawn": "1.000000000 SP", "deposited": "1.000000000 SCR"}`},
+	{types.ReturnScorumpowerDelegation, `{"account": "alice", "scorumpower": "1.000000000 SP"}`},
+
+	{types.WitnessUpdateOpType, `{"owner": "alice", "url": "https://alice.example", "block_signing_key": "` + key1 + `",
+		"props": {"account_creation_fee": "0.750000000 SCR", "maximum_block_size": 65536}}`},
+	{types.AccountWitnessVoteOpType, `{"account": "bob", "witness": "alice", "approve": true}`},
+	{types.AccountWitnessProxyOpType, `{"account": "bob", "proxy": "alice"}`},
+	{types.ProposalCreateOperation, `{"creator": "alice", "lifetime_sec": 86400, "operation": ["registration_committee_add_member", {"account_name":"bob"}]}`},
+	{types.ProposalVoteOperation, `{"voting_account": "bob", "proposal_id": 7}`},
+	{types.ProposalVirtual, `{"proposal_op": ["registration_committee_add_member", {"account_name":"bob"}]}`},
+	{types.ShutdownWitness, `{"owner": "alice"}`},
+	{types.WitnessMissBlock, `{"owner": "alice", "block_num": 100}`},
+
+	{types.AccountUpdateOpType, `{"account": "alice", "owner": {"weight_threshold": 1, "account_auths": [["bob", 1]], "key_auths": [["` + key1 + `", 1]]},
+		"memo_key": "` + key2 + `", "json_metadata": "{}"}`},
+	{types.RequestAccountRecovery, `{"recovery_account": "bob", "account_to_recover": "alice",
+		"new_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key1 + `", 1]]}}`},
+	{types.RecoverAccount, `{"account_to_recover": "alice",
+		"new_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key1 + `", 1]]},
+		"recent_owner_authority": {"weight_threshold": 1, "account_auths": [], "key_auths": [["` + key2 + `", 1]]}}`},
+	{types.ChangeRecoveryAccount, `{"account_to_recover": "alice", "new_recovery_account": "bob"}`},
+	{types.DeclineVotingRights, `{"account": "alice", "decline": true}`},
+	{types.ProveAuthority, `{"challenged": "alice", "require_owner": true}`},
+
+	{types.EscrowTransfer, `{"from": "alice", "to": "bob", "agent": "carol", "escrow_id": 7, "scorum_amount": "10.000000000 SCR", "fee": "0.100000000 SCR",
+		"ratification_deadline": "2018-08-01T10:00:00", "escrow_expiration": "2018-08-10T10:00:00", "json_meta": "{}"}`},
+	{types.EscrowApprove, `{"from": "alice", "to": "bob", "agent": "carol", "who": "carol", "escrow_id": 7, "approve": true}`},
+	{types.EscrowDispute, `{"from": "alice", "to": "bob", "agent": "carol", "who": "bob", "escrow_id": 7}`},
+	{types.EscrowRelease, `{"from": "alice", "to": "bob", "agent": "carol", "who": "carol", "receiver": "bob", "escrow_id": 7, "scorum_amount": "10.000000000 SCR"}`},
+	{types.AtomicswapInitiateOperation, `{"type": "by_participant", "owner": "alice", "recipient": "bob", "amount": "10.000000000 SCR", "secret_hash": "` + secretHash + `", "metadata": "btc"}`},
+	{types.AtomicswapRedeemOperation, `{"from": "alice", "to": "bob", "secret": "c2VjcmV0"}`},
+	{types.AtomicswapRefundOperation, `{"participant": "bob", "initiator": "alice", "secret_hash": "` + secretHash + `"}`},
+	{types.ExpiredContractRefund, `{"owner": "alice", "refund": "10.000000000 SCR"}`},
+
+	{types.CreateBudget, `{"type": "banner", "uuid": "` + budgetUUID + `", "owner": "alice", "json_metadata": "{}",
+		"balance": "10.000000000 SCR", "start": "2019-01-01T00:00:00", "deadline": "2019-02-01T00:00:00"}`},
+	{types.UpdateBudgetOperation, `{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "json_metadata": "{}"}`},
+	{types.CloseBudget, `{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice"}`},
+	{types.CloseBudgetByAdvertisingModeratorOperation, `{"type": "post", "uuid": "` + budgetUUID + `", "moderator": "bob"}`},
+	{types.AllocateCashFromAdvertisingBudget, `{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "cash": "0.500000000 SCR"}`},
+	{types.CashBackFromAdvertisingBudgetToOwner, `{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice", "cash": "0.500000000 SCR"}`},
+	{types.ClosingBudget, `{"type": "post", "uuid": "` + budgetUUID + `", "owner": "alice"}`},
+
+	{types.CommentOptionsOpType, `{"author": "alice", "permlink": "post", "max_accepted_payout": "1000000.000000000 SP", "percent_scrs": 10000,
+		"allow_votes": true, "allow_curation_rewards": true, "extensions": [[0, {"beneficiaries": [{"account": "bob", "weight": 1000}]}]]}`},
+	{types.AuthorReward, `{"author": "alice", "permlink": "post", "reward": "1.000000000 SP"}`},
+	{types.CommentReward, `{"author": "alice", "permlink": "post", "fund_reward": "4.000000000 SP", "total_payout": "3.000000000 SP",
+		"author_payout": "1.000000000 SP", "curators_payout": "1.000000000 SP", "beneficiaries_payout": "1.000000000 SP"}`},
+	{types.CurationReward, `{"curator": "bob", "reward": "1.000000000 SP", "comment_author": "alice", "comment_permlink": "post"}`},
+	{types.CommentBenefactorReward, `{"benefactor": "bob", "author": "alice", "permlink": "post", "reward": "1.000000000 SP"}`},
+	{types.CommentPayoutUpdate, `{"author": "alice", "permlink": "post"}`},
+	{types.ActiveSpHoldersRewardLegacy, `{"sp_holder": "alice", "reward": "1.000000000 SCR"}`},
+
+	{types.CreateGameRound, `{"owner": "alice", ` + nft + `, "verification_key": "key", "seed": "seed"}`},
+	{types.UpdateGameRoundResult, `{"owner": "alice", ` + nft + `, "proof": "proof", "vrf": "vrf", "result": 42}`},
+	{types.AdjustNFTExperience, `{"moderator": "alice", ` + nft + `, "experience": 10}`},
+	{types.UpdateNFTName, `{"moderator": "alice", ` + nft + `, "name": "truck"}`},
+	{types.UpdateGameMarkets, `{` + game + `, "markets": [["result_home", {}], ["total", {"threshold": 2500}]]}`},
+	{types.BetRestored, `{` + bet + `, "stake": "1.000000000 SCR"}`},
+	{types.BetUpdated, `{` + bet + `, "kind": "matched", "old_stake": "1.000000000 SCR", "new_stake": "0.500000000 SCR"}`},
+}
+
+// sampleEvents returns an event with every field set for every built-in type
+func sampleEvents(t *testing.T) []Event {
+	events := []Event{
+		UnknownEvent{OpType: "future_operation", Data: json.RawMessage(`{"owner":"alice"}`)},
+		&HardforkVersionChangedEvent{OldVersion: "0.1.0", NewVersion: "0.2.0"},
+		&MajorityVersionChangedEvent{OldVersion: "0.1.0", NewVersion: "0.2.0"},
+		&ChainPropertiesChangedEvent{
+			Old: ChainProperties{AccountCreationFee: *mustAsset(t, "0.750000000 SCR"), MaximumBlockSize: 65536},
+			New: ChainProperties{AccountCreationFee: *mustAsset(t, "1.000000000 SCR"), MaximumBlockSize: 131072},
+		},
+		&VoteCastEvent{Voter: "bob", Author: "alice", PermLink: "post", Weight: 10000},
+		&VoteChangedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 10000, Weight: 5000},
+		&VoteRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 5000},
+		&FlagCastEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: -5000, Weight: -10000},
+		&FlagRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: -10000, Weight: 10000},
+		&PostEditedEvent{Author: "alice", PermLink: "post", ParentPermLink: "football", PreviousTitle: "Title", Title: "New title",
+			PreviousBody: "Body", Body: "New body", Patch: "@@ -1,4 +1,8 @@\n+New \n Body\n", PreviousJsonMetadata: "{}", JsonMetadata: `{"tags":["football"]}`},
+		&CommentEditedEvent{Author: "bob", PermLink: "comment", ParentAuthor: "alice", ParentPermLink: "post",
+			PreviousBody: "Body", Body: "@@ -1,4 +1,4 @@\n-Nobody\n", Patch: "@@ -1,4 +1,4 @@\n-Nobody\n",
+			PreviousJsonMetadata: "{}", JsonMetadata: "{}", PatchError: "patch doesn't apply"},
+	}
+
+	for _, op := range sampleOperations {
+		ev, err := ToEvent(decodeOperation(t, op.opType, op.data))
+		require.NoError(t, err, op.opType)
+		events = append(events, ev)
+	}
+
+	return events
+}
+
+func mustAsset(t *testing.T, value string) *types.Asset {
+	asset, err := types.AssetFromString(value)
+	require.NoError(t, err)
+	return asset
+}
+
+func TestMarshalEvent_RoundTrip(t *testing.T) {
+	covered := make(map[Type]bool)
+	for _, ev := range sampleEvents(t) {
+		covered[ev.Type()] = true
+
+		data, err := MarshalEvent(ev)
+		require.NoError(t, err, ev.Type())
+
+		decoded, err := UnmarshalEvent(data)
+		require.NoError(t, err, string(data))
+		require.Equal(t, ev, decoded, string(data))
+
+		// the envelope is stable, encoding the decoded event gives the same document
+		again, err := MarshalEvent(decoded)
+		require.NoError(t, err)
+		require.JSONEq(t, string(data), string(again))
+	}
+
+	for _, bt := range builtinTypes {
+		if bt.prototype != nil {
+			require.True(t, covered[bt.eventType], "no sample for %s", bt.name)
+		}
+	}
+}
+
+func TestMarshalEvent_Envelope(t *testing.T) {
+	data, err := MarshalEvent(&VoteEvent{Voter: "bob", Author: "alice", PermLink: "post", Weight: 10000})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "vote", "version": 1, "payload": {"voter": "bob", "author": "alice", "permlink": "post", "weight": 10000}}`, string(data))
+
+	data, err = MarshalEvent(BetCancelledEvent{types.BetCancelledOperation{Better: "bob", Stake: *mustAsset(t, "1.000000000 SCR"), Kind: types.PendingBetKind}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "bet_cancelled", "version": 1, "payload": {
+		"game_uuid": "00000000-0000-0000-0000-000000000000", "better": "bob", "bet_uuid": "00000000-0000-0000-0000-000000000000",
+		"stake": "1.000000000 SCR", "kind": "pending"}}`, string(data))
+
+	// payloads use the same names for the same fields, whatever the node names them
+	data, err = MarshalEvent(&EscrowReleaseEvent{From: "alice", To: "bob", Agent: "carol", Who: "carol", Receiver: "bob", EscrowID: 7,
+		Amount: mustParseAsset(t, "10.000000000 SCR")})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "escrow_release", "version": 1, "payload": {"from": "alice", "to": "bob", "agent": "carol", "who": "carol",
+		"receiver": "bob", "escrow_id": 7, "amount": "10.000000000 SCR"}}`, string(data))
+
+	data, err = MarshalEvent(&CurationRewardEvent{Curator: "bob", Reward: mustParseAsset(t, "1.000000000 SP"), Author: "alice", PermLink: "post"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "curation_reward", "version": 1, "payload": {"curator": "bob", "reward": "1.000000000 SP",
+		"author": "alice", "permlink": "post"}}`, string(data))
+}
+
+func TestUnmarshalEvent_Errors(t *testing.T) {
+	_, err := UnmarshalEvent([]byte(`{"type": "vote", "version": 2, "payload": {}}`))
+	require.True(t, errors.Is(err, ErrUnsupportedSchemaVersion))
+
+	_, err = UnmarshalEvent([]byte(`{"type": "vote", "payload": {}}`))
+	require.True(t, errors.Is(err, ErrUnsupportedSchemaVersion))
+
+	_, err = UnmarshalEvent([]byte(`{"type": "no_such_event", "version": 1, "payload": {}}`))
+	require.True(t, errors.Is(err, ErrUnknownEventType))
+
+	_, err = UnmarshalEvent([]byte(`{"type": "increase_nft_power", "version": 1, "payload": {}}`))
+	require.True(t, errors.Is(err, ErrUnknownEventType))
+
+	_, err = UnmarshalEvent([]byte(`{"type": "vote", "version": 1, "payload": {"weight": "full"}}`))
+	require.Error(t, err)
+
+	_, err = MarshalEvent(producerRewardEvent{})
+	require.True(t, errors.Is(err, ErrUnknownEventType))
+}
+
+type customJSONEvent struct {
+	Producer string `json:"producer"`
+}
+
+var (
+	customJSONEventType = NewType()
+	// registered once, so the tests can be run several times
+	registerCustomJSON = RegisterType("custom_json", customJSONEvent{})
+)
+
+func (e customJSONEvent) Type() Type {
+	return customJSONEventType
+}
+
+func TestRegisterType(t *testing.T) {
+	require.NoError(t, registerCustomJSON)
+	require.True(t, errors.Is(RegisterType("custom_json", &producerRewardEvent{}), ErrTypeRegistered))
+	require.True(t, errors.Is(RegisterType("vote", &producerRewardEvent{}), ErrTypeRegistered))
+	require.True(t, errors.Is(RegisterType("another_vote", &VoteEvent{}), ErrTypeRegistered))
+
+	data, err := MarshalEvent(customJSONEvent{Producer: "alice"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "custom_json", "version": 1, "payload": {"producer": "alice"}}`, string(data))
+
+	ev, err := UnmarshalEvent(data)
+	require.NoError(t, err)
+	require.Equal(t, customJSONEvent{Producer: "alice"}, ev)
+}
+
+func TestBlock_JSON(t *testing.T) {
+	block := Block{
+		BlockNum:  100,
+		Timestamp: time.Date(2019, 6, 1, 18, 0, 0, 0, time.UTC),
+		Events:    sampleEvents(t),
+	}
+
+	data, err := json.Marshal(block)
+	require.NoError(t, err)
+
+	var decoded Block
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, block, decoded)
+
+	data, err = json.Marshal(Block{BlockNum: 1, Timestamp: block.Timestamp})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"block_num": 1, "timestamp": "2019-06-01T18:00:00Z", "events": []}`, string(data))
+}
+
+func TestConverters_Prototypes(t *testing.T) {
+	for _, ev := range sampleEvents(t) {
+		var prototype Event
+		for _, bt := range builtinTypes {
+			if bt.eventType == ev.Type() {
+				prototype = bt.prototype
+			}
+		}
+
+		// events are decoded into the Go type the converters produce
+		require.Equal(t, reflect.TypeOf(prototype), reflect.TypeOf(ev), ev.Type())
+	}
+}
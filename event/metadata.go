@@ -0,0 +1,133 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrMalformedMetadata is returned by the metadata accessors given json_metadata they can not decode
+var ErrMalformedMetadata = errors.New("malformed json metadata")
+
+// ContentMetadata is the json_metadata of a post or a comment, e.g.
+// {"tags": ["football"], "app": "scorum/1.0", "image": ["https://..."], "links": ["https://..."]}
+type ContentMetadata struct {
+	Tags   []string `json:"tags"`
+	App    string   `json:"app"`
+	Images []string `json:"image"`
+	Links  []string `json:"links"`
+}
+
+// UnmarshalJSON accepts the variants written by older apps: a single tag or image instead of a list and "images" instead of "image"
+func (m *ContentMetadata) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Tags   stringList `json:"tags"`
+		App    string     `json:"app"`
+		Image  stringList `json:"image"`
+		Images stringList `json:"images"`
+		Links  stringList `json:"links"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = ContentMetadata{
+		Tags:   v.Tags,
+		App:    v.App,
+		Images: append(v.Image, v.Images...),
+		Links:  v.Links,
+	}
+	return nil
+}
+
+// GameMetadata is the json_metadata of a game, e.g. {"home": "Juventus", "away": "Napoli", "league": "Serie A", "sport": "soccer"}
+type GameMetadata struct {
+	Home   string `json:"home"`
+	Away   string `json:"away"`
+	League string `json:"league"`
+	Sport  string `json:"sport"`
+}
+
+// NFTMetadata is the json_metadata of an NFT, every key of the object is an attribute, e.g. {"color": "red", "speed": 100}
+type NFTMetadata struct {
+	Attributes map[string]json.RawMessage
+}
+
+// Attribute decodes the attribute into v, it returns false if the NFT has no such attribute
+func (m NFTMetadata) Attribute(name string, v interface{}) (bool, error) {
+	raw, ok := m.Attributes[name]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, fmt.Errorf("%w: attribute %s: %s", ErrMalformedMetadata, name, err)
+	}
+	return true, nil
+}
+
+// Metadata decodes JsonMetadata, an empty JsonMetadata is empty metadata
+func (e PostEvent) Metadata() (ContentMetadata, error) {
+	var m ContentMetadata
+	err := unmarshalMetadata(e.JsonMetadata, &m)
+	return m, err
+}
+
+// Metadata decodes JsonMetadata, an empty JsonMetadata is empty metadata
+func (e CommentEvent) Metadata() (ContentMetadata, error) {
+	var m ContentMetadata
+	err := unmarshalMetadata(e.JsonMetadata, &m)
+	return m, err
+}
+
+// Metadata decodes JsonMetadata, an empty JsonMetadata is empty metadata
+func (e CreateGameEvent) Metadata() (GameMetadata, error) {
+	var m GameMetadata
+	err := unmarshalMetadata(e.JsonMetadata, &m)
+	return m, err
+}
+
+// Metadata decodes JSONMetadata, an empty JSONMetadata is empty metadata
+func (e CreateNFTEvent) Metadata() (NFTMetadata, error) {
+	var m NFTMetadata
+	err := unmarshalMetadata(e.JSONMetadata, &m.Attributes)
+	return m, err
+}
+
+// Metadata decodes JSONMetadata, the new metadata of the NFT
+func (e UpdateNFTMetadataEvent) Metadata() (NFTMetadata, error) {
+	var m NFTMetadata
+	err := unmarshalMetadata(e.JSONMetadata, &m.Attributes)
+	return m, err
+}
+
+func unmarshalMetadata(raw string, v interface{}) error {
+	if raw == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		return fmt.Errorf("%w: %s", ErrMalformedMetadata, err)
+	}
+	return nil
+}
+
+// stringList is a list of strings or a single string
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s != "" {
+			*l = stringList{s}
+		}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
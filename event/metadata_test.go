@@ -0,0 +1,80 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentMetadata(t *testing.T) {
+	post := PostEvent{JsonMetadata: `{"tags": ["football", "news"], "app": "scorum/1.0", "image": ["https://img.example/1.png"], "links": ["https://scorum.com"]}`}
+
+	m, err := post.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, ContentMetadata{
+		Tags:   []string{"football", "news"},
+		App:    "scorum/1.0",
+		Images: []string{"https://img.example/1.png"},
+		Links:  []string{"https://scorum.com"},
+	}, m)
+
+	comment := CommentEvent{JsonMetadata: `{"tags": "football", "images": "https://img.example/2.png", "format": "markdown"}`}
+
+	m, err = comment.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, ContentMetadata{
+		Tags:   []string{"football"},
+		Images: []string{"https://img.example/2.png"},
+	}, m)
+
+	m, err = PostEvent{}.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, ContentMetadata{}, m)
+
+	_, err = PostEvent{JsonMetadata: `{"tags": `}.Metadata()
+	require.ErrorIs(t, err, ErrMalformedMetadata)
+
+	_, err = CommentEvent{JsonMetadata: `{"tags": [1]}`}.Metadata()
+	require.ErrorIs(t, err, ErrMalformedMetadata)
+}
+
+func TestGameMetadata(t *testing.T) {
+	e := CreateGameEvent{types.CreateGameOperation{
+		JsonMetadata: `{"home": "Juventus", "away": "Napoli", "league": "Serie A", "sport": "soccer", "id": 7}`,
+	}}
+
+	m, err := e.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, GameMetadata{Home: "Juventus", Away: "Napoli", League: "Serie A", Sport: "soccer"}, m)
+	require.Contains(t, e.JsonMetadata, `"id": 7`)
+
+	e.JsonMetadata = `[]`
+	_, err = e.Metadata()
+	require.ErrorIs(t, err, ErrMalformedMetadata)
+}
+
+func TestNFTMetadata(t *testing.T) {
+	e := CreateNFTEvent{types.CreateNFTOperation{JSONMetadata: `{"color": "red", "speed": 100}`}}
+
+	m, err := e.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, map[string]json.RawMessage{"color": json.RawMessage(`"red"`), "speed": json.RawMessage(`100`)}, m.Attributes)
+
+	var speed int
+	found, err := m.Attribute("speed", &speed)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 100, speed)
+
+	found, err = m.Attribute("wheels", &speed)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, err = m.Attribute("color", &speed)
+	require.ErrorIs(t, err, ErrMalformedMetadata)
+
+	_, err = UpdateNFTMetadataEvent{types.UpdateNFTMetadataOperation{JSONMetadata: `"red"`}}.Metadata()
+	require.ErrorIs(t, err, ErrMalformedMetadata)
+}
@@ -0,0 +1,105 @@
+package event
+
+import "github.com/scorum/scorum-go/types"
+
+type CreateNFTEvent struct {
+	types.CreateNFTOperation
+}
+
+func (e CreateNFTEvent) Type() Type {
+	return CreateNFTEventType
+}
+
+func toCreateNFTEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.CreateNFTOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return CreateNFTEvent{*e}, nil
+}
+
+type UpdateNFTMetadataEvent struct {
+	types.UpdateNFTMetadataOperation
+}
+
+func (e UpdateNFTMetadataEvent) Type() Type {
+	return UpdateNFTMetadataEventType
+}
+
+func toUpdateNFTMetadataEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.UpdateNFTMetadataOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return UpdateNFTMetadataEvent{*e}, nil
+}
+
+type AdjustNFTExperienceEvent struct {
+	types.AdjustNFTExperienceOperation
+}
+
+func (e AdjustNFTExperienceEvent) Type() Type {
+	return AdjustNFTExperienceEventType
+}
+
+func toAdjustNFTExperienceEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.AdjustNFTExperienceOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return AdjustNFTExperienceEvent{*e}, nil
+}
+
+type UpdateNFTNameEvent struct {
+	types.UpdateNFTNameOperation
+}
+
+func (e UpdateNFTNameEvent) Type() Type {
+	return UpdateNFTNameEventType
+}
+
+func toUpdateNFTNameEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.UpdateNFTNameOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return UpdateNFTNameEvent{*e}, nil
+}
+
+type CreateGameRoundEvent struct {
+	types.CreateGameRoundOperation
+}
+
+func (e CreateGameRoundEvent) Type() Type {
+	return CreateGameRoundEventType
+}
+
+func toCreateGameRoundEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.CreateGameRoundOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return CreateGameRoundEvent{*e}, nil
+}
+
+type UpdateGameRoundResultEvent struct {
+	types.UpdateGameRoundResultOperation
+}
+
+func (e UpdateGameRoundResultEvent) Type() Type {
+	return UpdateGameRoundResultEventType
+}
+
+func toUpdateGameRoundResultEvent(op types.Operation) (Event, error) {
+	e, ok := op.(*types.UpdateGameRoundResultOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return UpdateGameRoundResultEvent{*e}, nil
+}
@@ -0,0 +1,94 @@
+package event
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// Converter converts an operation into an event, an operation it can not handle results in an error
+type Converter func(operation types.Operation) (Event, error)
+
+// DefaultRegistry is the registry used by ToEvent, Register and RegisteredOpTypes
+var DefaultRegistry = NewRegistry()
+
+// Registry maps operation types to converters. It is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	converters map[types.OpType]Converter
+}
+
+// NewRegistry creates a registry with the built-in converters
+func NewRegistry() *Registry {
+	converters := make(map[types.OpType]Converter, len(builtinConverters))
+	for opType, converter := range builtinConverters {
+		converters[opType] = converter
+	}
+
+	return &Registry{
+		converters: converters,
+	}
+}
+
+// Register sets the converter of the operation type, a built-in converter is overridden.
+// It panics for a nil converter, Unregister removes a converter.
+func (r *Registry) Register(opType types.OpType, converter Converter) {
+	if converter == nil {
+		panic("event: nil converter registered for " + string(opType))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.converters[opType] = converter
+}
+
+// Unregister removes the converter of the operation type, the operation is converted into UnknownEvent
+func (r *Registry) Unregister(opType types.OpType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.converters, opType)
+}
+
+// OpTypes returns the sorted operation types having a converter
+func (r *Registry) OpTypes() []types.OpType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	opTypes := make([]types.OpType, 0, len(r.converters))
+	for opType := range r.converters {
+		opTypes = append(opTypes, opType)
+	}
+	sort.Slice(opTypes, func(i, j int) bool { return opTypes[i] < opTypes[j] })
+
+	return opTypes
+}
+
+// ToEvent converts the operation, operations without a converter are converted into UnknownEvent
+func (r *Registry) ToEvent(op types.Operation) (Event, error) {
+	if op == nil {
+		return nil, ErrWrongOperationType
+	}
+
+	r.mu.RLock()
+	converter, exists := r.converters[op.Type()]
+	r.mu.RUnlock()
+
+	if exists {
+		return converter(op)
+	}
+
+	return toUnknownEvent(op)
+}
+
+// Register sets the converter of the operation type in the DefaultRegistry, it panics for a nil converter
+func Register(opType types.OpType, converter Converter) {
+	DefaultRegistry.Register(opType, converter)
+}
+
+// RegisteredOpTypes returns the operation types having a converter in the DefaultRegistry
+func RegisteredOpTypes() []types.OpType {
+	return DefaultRegistry.OpTypes()
+}
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var producerRewardEventType = NewType()
+
+type producerRewardEvent struct {
+	Producer string
+}
+
+func (e producerRewardEvent) Type() Type {
+	return producerRewardEventType
+}
+
+func toProducerRewardEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.ProducerRewardOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return producerRewardEvent{Producer: v.Producer}, nil
+}
+
+func mustToEvent(t *testing.T, registry *Registry, op types.Operation) Event {
+	ev, err := registry.ToEvent(op)
+	require.NoError(t, err)
+	return ev
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	op := &types.ProducerRewardOperation{Producer: "alice"}
+
+	require.Equal(t, UnknownEventType, mustToEvent(t, registry, op).Type())
+
+	registry.Register(types.ProducerRewardOpType, toProducerRewardEvent)
+	require.Equal(t, producerRewardEvent{Producer: "alice"}, mustToEvent(t, registry, op))
+	require.Contains(t, registry.OpTypes(), types.ProducerRewardOpType)
+
+	registry.Unregister(types.ProducerRewardOpType)
+	require.Equal(t, UnknownEventType, mustToEvent(t, registry, op).Type())
+	require.NotContains(t, registry.OpTypes(), types.ProducerRewardOpType)
+
+	// the default registry is not affected
+	require.NotContains(t, RegisteredOpTypes(), types.ProducerRewardOpType)
+
+	require.Panics(t, func() { registry.Register(types.ProducerRewardOpType, nil) })
+	require.NotContains(t, registry.OpTypes(), types.ProducerRewardOpType)
+}
+
+func TestRegistry_Override(t *testing.T) {
+	registry := NewRegistry()
+	op := &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: -100}
+
+	require.Equal(t, FlagEventType, mustToEvent(t, registry, op).Type())
+
+	registry.Register(types.VoteOpType, func(op types.Operation) (Event, error) {
+		v := op.(*types.VoteOperation)
+		return &VoteEvent{Voter: v.Voter, Author: v.Author, PermLink: v.Permlink, Weight: v.Weight}, nil
+	})
+	require.Equal(t, VoteEventType, mustToEvent(t, registry, op).Type())
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	registry := NewRegistry()
+	op := &types.ProducerRewardOperation{Producer: "alice"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registry.Register(types.ProducerRewardOpType, toProducerRewardEvent)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = registry.ToEvent(op)
+			registry.OpTypes()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNewType(t *testing.T) {
+	a, b := NewType(), NewType()
+
+	require.True(t, a >= FirstCustomType)
+	require.True(t, producerRewardEventType >= FirstCustomType)
+	require.NotEqual(t, a, b)
+}
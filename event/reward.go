@@ -0,0 +1,225 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// Beneficiary gets Weight (in basis points) of the author reward of a comment
+type Beneficiary struct {
+	Account string `json:"account"`
+	Weight  uint16 `json:"weight"`
+}
+
+// CommentOptionsEvent, the beneficiaries are taken from the comment_payout_beneficiaries extension
+type CommentOptionsEvent struct {
+	Author               string        `json:"author"`
+	PermLink             string        `json:"permlink"`
+	MaxAcceptedPayout    Asset         `json:"max_accepted_payout"`
+	PercentSCRs          uint16        `json:"percent_scrs"`
+	AllowVotes           bool          `json:"allow_votes"`
+	AllowCurationRewards bool          `json:"allow_curation_rewards"`
+	Beneficiaries        []Beneficiary `json:"beneficiaries"`
+}
+
+func (e CommentOptionsEvent) Type() Type {
+	return CommentOptionsEventType
+}
+
+func toCommentOptionsEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.CommentOptionsOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	maxAcceptedPayout, err := parseAsset(v.MaxAcceptedPayout)
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiaries, err := toBeneficiaries(v.Extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentOptionsEvent{
+		Author:               v.Author,
+		PermLink:             v.Permlink,
+		MaxAcceptedPayout:    maxAcceptedPayout,
+		PercentSCRs:          v.PercentSCRs,
+		AllowVotes:           v.AllowVotes,
+		AllowCurationRewards: v.AllowCurationRewards,
+		Beneficiaries:        beneficiaries,
+	}, nil
+}
+
+// toBeneficiaries finds the beneficiaries in the [kind, value] extensions, other extensions are ignored
+func toBeneficiaries(extensions []interface{}) ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+	for _, extension := range extensions {
+		tuple, ok := extension.([]interface{})
+		if !ok || len(tuple) != 2 {
+			return nil, fmt.Errorf("%w: invalid comment options extension %v", ErrMalformedOperation, extension)
+		}
+
+		value, ok := tuple[1].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		list, ok := value["beneficiaries"]
+		if !ok {
+			continue
+		}
+
+		data, err := json.Marshal(list)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrMalformedOperation, err)
+		}
+
+		var b []Beneficiary
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrMalformedOperation, err)
+		}
+		beneficiaries = append(beneficiaries, b...)
+	}
+
+	return beneficiaries, nil
+}
+
+// AuthorRewardEvent is produced by the author_reward virtual operation
+type AuthorRewardEvent struct {
+	Author   string `json:"author"`
+	PermLink string `json:"permlink"`
+	Reward   Asset  `json:"reward"`
+}
+
+func (e AuthorRewardEvent) Type() Type {
+	return AuthorRewardEventType
+}
+
+func toAuthorRewardEvent(op types.Operation) (Event, error) {
+	var e AuthorRewardEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CommentRewardEvent is produced by the comment_reward virtual operation,
+// it splits the total payout of a comment between its author, curators and beneficiaries
+type CommentRewardEvent struct {
+	Author              string `json:"author"`
+	PermLink            string `json:"permlink"`
+	FundReward          Asset  `json:"fund_reward"`
+	TotalPayout         Asset  `json:"total_payout"`
+	AuthorPayout        Asset  `json:"author_payout"`
+	CuratorsPayout      Asset  `json:"curators_payout"`
+	BeneficiariesPayout Asset  `json:"beneficiaries_payout"`
+}
+
+func (e CommentRewardEvent) Type() Type {
+	return CommentRewardEventType
+}
+
+func toCommentRewardEvent(op types.Operation) (Event, error) {
+	var e CommentRewardEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CurationRewardEvent is produced by the curation_reward virtual operation
+type CurationRewardEvent struct {
+	Curator  string `json:"curator"`
+	Reward   Asset  `json:"reward"`
+	Author   string `json:"author"`
+	PermLink string `json:"permlink"`
+}
+
+func (e CurationRewardEvent) Type() Type {
+	return CurationRewardEventType
+}
+
+func toCurationRewardEvent(op types.Operation) (Event, error) {
+	var v struct {
+		Curator  string `json:"curator"`
+		Reward   Asset  `json:"reward"`
+		Author   string `json:"comment_author"`
+		PermLink string `json:"comment_permlink"`
+	}
+	if err := unmarshalUnknownOperation(op, &v); err != nil {
+		return nil, err
+	}
+
+	return &CurationRewardEvent{
+		Curator:  v.Curator,
+		Reward:   v.Reward,
+		Author:   v.Author,
+		PermLink: v.PermLink,
+	}, nil
+}
+
+// CommentBenefactorRewardEvent is produced by the comment_benefactor_reward virtual operation
+type CommentBenefactorRewardEvent struct {
+	Benefactor string `json:"benefactor"`
+	Author     string `json:"author"`
+	PermLink   string `json:"permlink"`
+	Reward     Asset  `json:"reward"`
+}
+
+func (e CommentBenefactorRewardEvent) Type() Type {
+	return CommentBenefactorRewardEventType
+}
+
+func toCommentBenefactorRewardEvent(op types.Operation) (Event, error) {
+	var e CommentBenefactorRewardEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CommentPayoutUpdateEvent is produced by the comment_payout_update virtual operation once a comment is paid out
+type CommentPayoutUpdateEvent struct {
+	Author   string `json:"author"`
+	PermLink string `json:"permlink"`
+}
+
+func (e CommentPayoutUpdateEvent) Type() Type {
+	return CommentPayoutUpdateEventType
+}
+
+func toCommentPayoutUpdateEvent(op types.Operation) (Event, error) {
+	var e CommentPayoutUpdateEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ActiveSPHoldersRewardLegacyEvent is produced by the active_sp_holders_reward_legacy virtual operation
+type ActiveSPHoldersRewardLegacyEvent struct {
+	SPHolder string `json:"sp_holder"`
+	Reward   Asset  `json:"reward"`
+}
+
+func (e ActiveSPHoldersRewardLegacyEvent) Type() Type {
+	return ActiveSPHoldersRewardLegacyEventType
+}
+
+func toActiveSPHoldersRewardLegacyEvent(op types.Operation) (Event, error) {
+	var e ActiveSPHoldersRewardLegacyEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
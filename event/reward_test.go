@@ -0,0 +1,134 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentOptionsEvent(t *testing.T) {
+	var op types.CommentOptionsOperation
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"author": "alice",
+		"permlink": "post",
+		"max_accepted_payout": "1000000.000000000 SP",
+		"percent_scrs": 10000,
+		"allow_votes": true,
+		"allow_curation_rewards": true,
+		"extensions": [[0, {"beneficiaries": [{"account": "bob", "weight": 1000}, {"account": "carol", "weight": 500}]}]]
+	}`), &op))
+
+	ev, err := toCommentOptionsEvent(&op)
+	require.NoError(t, err)
+	require.Equal(t, &CommentOptionsEvent{
+		Author:               "alice",
+		PermLink:             "post",
+		MaxAcceptedPayout:    mustParseAsset(t, "1000000.000000000 SP"),
+		PercentSCRs:          10000,
+		AllowVotes:           true,
+		AllowCurationRewards: true,
+		Beneficiaries:        []Beneficiary{{Account: "bob", Weight: 1000}, {Account: "carol", Weight: 500}},
+	}, ev)
+
+	op.Extensions = []interface{}{"beneficiaries"}
+	_, err = toCommentOptionsEvent(&op)
+	require.ErrorIs(t, err, ErrMalformedOperation)
+}
+
+func TestRewardEvents(t *testing.T) {
+	sp := mustParseAsset(t, "1.000000000 SP")
+	scr := mustParseAsset(t, "1.000000000 SCR")
+
+	testConverters(t, []converterCase{
+		{
+			name:      "author reward",
+			converter: toAuthorRewardEvent,
+			op:        rawOperation(`{"author": "alice", "permlink": "post", "reward": "1.000000000 SP"}`),
+			expected:  &AuthorRewardEvent{Author: "alice", PermLink: "post", Reward: sp},
+		},
+		{
+			name:      "comment reward",
+			converter: toCommentRewardEvent,
+			op: rawOperation(`{"author": "alice", "permlink": "post", "fund_reward": "4.000000000 SP", "total_payout": "3.000000000 SP",
+				"author_payout": "1.000000000 SP", "curators_payout": "1.000000000 SP", "beneficiaries_payout": "1.000000000 SP"}`),
+			expected: &CommentRewardEvent{
+				Author:              "alice",
+				PermLink:            "post",
+				FundReward:          mustParseAsset(t, "4.000000000 SP"),
+				TotalPayout:         mustParseAsset(t, "3.000000000 SP"),
+				AuthorPayout:        sp,
+				CuratorsPayout:      sp,
+				BeneficiariesPayout: sp,
+			},
+		},
+		{
+			name:      "comment reward without beneficiaries payout",
+			converter: toCommentRewardEvent,
+			op: rawOperation(`{"author": "alice", "permlink": "post", "fund_reward": "2.000000000 SP", "total_payout": "2.000000000 SP",
+				"author_payout": "1.000000000 SP", "curators_payout": "1.000000000 SP"}`),
+			expected: &CommentRewardEvent{
+				Author:         "alice",
+				PermLink:       "post",
+				FundReward:     mustParseAsset(t, "2.000000000 SP"),
+				TotalPayout:    mustParseAsset(t, "2.000000000 SP"),
+				AuthorPayout:   sp,
+				CuratorsPayout: sp,
+			},
+		},
+		{
+			name:      "curation reward",
+			converter: toCurationRewardEvent,
+			op:        rawOperation(`{"curator": "bob", "reward": "1.000000000 SCR", "comment_author": "alice", "comment_permlink": "post"}`),
+			expected:  &CurationRewardEvent{Curator: "bob", Reward: scr, Author: "alice", PermLink: "post"},
+		},
+		{
+			name:      "comment benefactor reward",
+			converter: toCommentBenefactorRewardEvent,
+			op:        rawOperation(`{"benefactor": "bob", "author": "alice", "permlink": "post", "reward": "1.000000000 SP"}`),
+			expected:  &CommentBenefactorRewardEvent{Benefactor: "bob", Author: "alice", PermLink: "post", Reward: sp},
+		},
+		{
+			name:      "comment payout update",
+			converter: toCommentPayoutUpdateEvent,
+			op:        rawOperation(`{"author": "alice", "permlink": "post"}`),
+			expected:  &CommentPayoutUpdateEvent{Author: "alice", PermLink: "post"},
+		},
+		{
+			name:      "active sp holders reward legacy",
+			converter: toActiveSPHoldersRewardLegacyEvent,
+			op:        rawOperation(`{"sp_holder": "alice", "reward": "1.000000000 SCR"}`),
+			expected:  &ActiveSPHoldersRewardLegacyEvent{SPHolder: "alice", Reward: scr},
+		},
+	})
+}
+
+func TestRewardEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "malformed reward",
+			converter: toAuthorRewardEvent,
+			op:        rawOperation(`{"author": "alice", "permlink": "post", "reward": "one SP"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "malformed payload",
+			converter: toCommentPayoutUpdateEvent,
+			op:        rawOperation(`"alice/post"`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "raw operation of a scorum-go operation",
+			converter: toCommentOptionsEvent,
+			op:        rawOperation(`{"author": "alice", "permlink": "post"}`),
+			err:       ErrWrongOperationType,
+		},
+		{
+			name:      "scorum-go operation of a raw operation",
+			converter: toCurationRewardEvent,
+			op:        &types.CommentOptionsOperation{Author: "alice", Permlink: "post"},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
@@ -0,0 +1,198 @@
+package event
+
+import "github.com/scorum/scorum-go/types"
+
+// TransferToScorumpowerEvent
+type TransferToScorumpowerEvent struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount Asset  `json:"amount"`
+}
+
+func (e TransferToScorumpowerEvent) Type() Type {
+	return TransferToScorumpowerEventType
+}
+
+func toTransferToScorumpowerEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.TransferToScorumpowerOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	amount, err := parseAsset(v.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferToScorumpowerEvent{
+		From:   v.From,
+		To:     v.To,
+		Amount: amount,
+	}, nil
+}
+
+// WithdrawScorumpowerEvent
+type WithdrawScorumpowerEvent struct {
+	Account     string `json:"account"`
+	Scorumpower Asset  `json:"scorumpower"`
+}
+
+func (e WithdrawScorumpowerEvent) Type() Type {
+	return WithdrawScorumpowerEventType
+}
+
+func toWithdrawScorumpowerEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.WithdrawScorumpowerOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	scorumpower, err := parseAsset(v.Scorumpower)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WithdrawScorumpowerEvent{
+		Account:     v.Account,
+		Scorumpower: scorumpower,
+	}, nil
+}
+
+// DelegateScorumpowerEvent
+type DelegateScorumpowerEvent struct {
+	Delegator   string `json:"delegator"`
+	Delegatee   string `json:"delegatee"`
+	Scorumpower Asset  `json:"scorumpower"`
+}
+
+func (e DelegateScorumpowerEvent) Type() Type {
+	return DelegateScorumpowerEventType
+}
+
+func toDelegateScorumpowerEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.DelegateScorumpowerOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	scorumpower, err := parseAsset(v.Scorumpower)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DelegateScorumpowerEvent{
+		Delegator:   v.Delegator,
+		Delegatee:   v.Delegatee,
+		Scorumpower: scorumpower,
+	}, nil
+}
+
+// DelegateSPFromRegPoolEvent
+type DelegateSPFromRegPoolEvent struct {
+	RegCommitteeMember string `json:"reg_committee_member"`
+	Delegatee          string `json:"delegatee"`
+	Scorumpower        Asset  `json:"scorumpower"`
+}
+
+func (e DelegateSPFromRegPoolEvent) Type() Type {
+	return DelegateSPFromRegPoolEventType
+}
+
+func toDelegateSPFromRegPoolEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.DelegateSPFromRegPoolOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	scorumpower, err := parseAsset(v.Scorumpower)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DelegateSPFromRegPoolEvent{
+		RegCommitteeMember: v.RegCommitteeMember,
+		Delegatee:          v.Delegatee,
+		Scorumpower:        scorumpower,
+	}, nil
+}
+
+// SetWithdrawScorumpowerRouteToAccountEvent
+type SetWithdrawScorumpowerRouteToAccountEvent struct {
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Percent     uint16 `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+func (e SetWithdrawScorumpowerRouteToAccountEvent) Type() Type {
+	return SetWithdrawScorumpowerRouteToAccountEventType
+}
+
+func toSetWithdrawScorumpowerRouteToAccountEvent(op types.Operation) (Event, error) {
+	var e SetWithdrawScorumpowerRouteToAccountEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// SetWithdrawScorumpowerRouteToDevPoolEvent
+type SetWithdrawScorumpowerRouteToDevPoolEvent struct {
+	FromAccount string `json:"from_account"`
+	Percent     uint16 `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+func (e SetWithdrawScorumpowerRouteToDevPoolEvent) Type() Type {
+	return SetWithdrawScorumpowerRouteToDevPoolEventType
+}
+
+func toSetWithdrawScorumpowerRouteToDevPoolEvent(op types.Operation) (Event, error) {
+	var e SetWithdrawScorumpowerRouteToDevPoolEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// FillScorumpowerWithdrawEvent is produced by the fill_scorumpower_withdraw virtual operation
+type FillScorumpowerWithdrawEvent struct {
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Withdrawn   Asset  `json:"withdrawn"`
+	Deposited   Asset  `json:"deposited"`
+}
+
+func (e FillScorumpowerWithdrawEvent) Type() Type {
+	return FillScorumpowerWithdrawEventType
+}
+
+func toFillScorumpowerWithdrawEvent(op types.Operation) (Event, error) {
+	var e FillScorumpowerWithdrawEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ReturnScorumpowerDelegationEvent is produced by the return_scorumpower_delegation virtual operation
+type ReturnScorumpowerDelegationEvent struct {
+	Account     string `json:"account"`
+	Scorumpower Asset  `json:"scorumpower"`
+}
+
+func (e ReturnScorumpowerDelegationEvent) Type() Type {
+	return ReturnScorumpowerDelegationEventType
+}
+
+func toReturnScorumpowerDelegationEvent(op types.Operation) (Event, error) {
+	var e ReturnScorumpowerDelegationEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,146 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseAsset(t *testing.T, value string) Asset {
+	asset, err := ParseAsset(value)
+	require.NoError(t, err)
+	return asset
+}
+
+// rawOperation is an operation unknown to scorum-go, as the node API decodes it
+func rawOperation(data string) *types.UnknownOperation {
+	return &types.UnknownOperation{Data: json.RawMessage(data)}
+}
+
+// converterCase is an operation and the event its converter returns, or the error it fails with
+type converterCase struct {
+	name      string
+	converter Converter
+	op        types.Operation
+	expected  Event
+	err       error
+}
+
+func testConverters(t *testing.T, cases []converterCase) {
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ev, err := c.converter(c.op)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+				require.Nil(t, ev)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.expected, ev)
+		})
+	}
+}
+
+func TestScorumpowerEvents(t *testing.T) {
+	sp := mustParseAsset(t, "10.000000000 SP")
+	scr := mustParseAsset(t, "10.000000000 SCR")
+
+	testConverters(t, []converterCase{
+		{
+			name:      "transfer to scorumpower",
+			converter: toTransferToScorumpowerEvent,
+			op:        &types.TransferToScorumpowerOperation{From: "alice", To: "bob", Amount: "10.000000000 SCR"},
+			expected:  &TransferToScorumpowerEvent{From: "alice", To: "bob", Amount: scr},
+		},
+		{
+			name:      "withdraw scorumpower",
+			converter: toWithdrawScorumpowerEvent,
+			op:        &types.WithdrawScorumpowerOperation{Account: "alice", Scorumpower: "10.000000000 SP"},
+			expected:  &WithdrawScorumpowerEvent{Account: "alice", Scorumpower: sp},
+		},
+		{
+			name:      "delegate scorumpower",
+			converter: toDelegateScorumpowerEvent,
+			op:        &types.DelegateScorumpowerOperation{Delegator: "alice", Delegatee: "bob", Scorumpower: "10.000000000 SP"},
+			expected:  &DelegateScorumpowerEvent{Delegator: "alice", Delegatee: "bob", Scorumpower: sp},
+		},
+		{
+			name:      "delegate sp from reg pool",
+			converter: toDelegateSPFromRegPoolEvent,
+			op:        &types.DelegateSPFromRegPoolOperation{RegCommitteeMember: "alice", Delegatee: "bob", Scorumpower: "10.000000000 SP"},
+			expected:  &DelegateSPFromRegPoolEvent{RegCommitteeMember: "alice", Delegatee: "bob", Scorumpower: sp},
+		},
+		{
+			name:      "set withdraw scorumpower route to account",
+			converter: toSetWithdrawScorumpowerRouteToAccountEvent,
+			op:        rawOperation(`{"from_account": "alice", "to_account": "bob", "percent": 5000, "auto_vest": true}`),
+			expected:  &SetWithdrawScorumpowerRouteToAccountEvent{FromAccount: "alice", ToAccount: "bob", Percent: 5000, AutoVest: true},
+		},
+		{
+			name:      "set withdraw scorumpower route to dev pool",
+			converter: toSetWithdrawScorumpowerRouteToDevPoolEvent,
+			op:        rawOperation(`{"from_account": "alice", "percent": 10000, "auto_vest": false}`),
+			expected:  &SetWithdrawScorumpowerRouteToDevPoolEvent{FromAccount: "alice", Percent: 10000},
+		},
+		{
+			name:      "set withdraw scorumpower route without auto vest",
+			converter: toSetWithdrawScorumpowerRouteToAccountEvent,
+			op:        rawOperation(`{"from_account": "alice", "to_account": "bob", "percent": 0}`),
+			expected:  &SetWithdrawScorumpowerRouteToAccountEvent{FromAccount: "alice", ToAccount: "bob"},
+		},
+		{
+			name:      "fill scorumpower withdraw",
+			converter: toFillScorumpowerWithdrawEvent,
+			op:        rawOperation(`{"from_account": "alice", "to_account": "bob", "withdrawn": "10.000000000 SP", "deposited": "10.000000000 SCR"}`),
+			expected:  &FillScorumpowerWithdrawEvent{FromAccount: "alice", ToAccount: "bob", Withdrawn: sp, Deposited: scr},
+		},
+		{
+			name:      "return scorumpower delegation",
+			converter: toReturnScorumpowerDelegationEvent,
+			op:        rawOperation(`{"account": "alice", "scorumpower": "10.000000000 SP"}`),
+			expected:  &ReturnScorumpowerDelegationEvent{Account: "alice", Scorumpower: sp},
+		},
+	})
+}
+
+func TestScorumpowerEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "malformed asset",
+			converter: toWithdrawScorumpowerEvent,
+			op:        &types.WithdrawScorumpowerOperation{Account: "alice", Scorumpower: "ten SP"},
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "raw asset without symbol",
+			converter: toReturnScorumpowerDelegationEvent,
+			op:        rawOperation(`{"account": "alice", "scorumpower": "10"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "raw operation of a scorum-go operation",
+			converter: toDelegateScorumpowerEvent,
+			op:        rawOperation(`{"delegator": "alice", "delegatee": "bob", "scorumpower": "10.000000000 SP"}`),
+			err:       ErrWrongOperationType,
+		},
+		{
+			name:      "scorum-go operation of a raw operation",
+			converter: toFillScorumpowerWithdrawEvent,
+			op:        &types.WithdrawScorumpowerOperation{Account: "alice", Scorumpower: "10.000000000 SP"},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
+
+func TestParseAsset(t *testing.T) {
+	asset, err := ParseAsset("1.500000000 SP")
+	require.NoError(t, err)
+	require.Equal(t, SPSymbol, asset.Symbol)
+	require.Equal(t, "1.500000000 SP", asset.String())
+
+	_, err = ParseAsset("1.5")
+	require.Error(t, err)
+}
@@ -1,28 +1,346 @@
 package event
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Type is the type of an event. The numbers of the built-in types are persisted by consumers and must not be
+// changed, every built-in type has an explicit number and new types take the next free one.
+// Types are named by stable snake_case names, e.g. "post_bet".
 type Type int
 
+var (
+	// ErrUnknownEventType is returned for an event type without a registered name
+	ErrUnknownEventType = errors.New("unknown event type")
+	// ErrTypeRegistered is returned by RegisterType for a name or a type registered before
+	ErrTypeRegistered = errors.New("event type already registered")
+)
+
 const (
-	UnknownEventType Type = iota
-	AccountCreateEventType
-	PostEventType
-	CommentEventType
-	VoteEventType
-	FlagEventType
-	DeleteCommentEventType
-	CreateGameEventType
-	CancelGameEventType
-	UpdateGameStartEventType
-	PostGameResultsEventType
-	PostBetEventType
-	CancelPendingBetsEventType
-	BetsMatchedEventType
-	GameStatusChangedEventType
-	BetResolvedEventType
-	BetCancelledEventType
-	TransferEventType
-	CreateNFTEventType
-	UpdateNFTMetadataEventType
-	IncreaseNFTPowerEventType
-	BurnEventType
+	UnknownEventType           Type = 0
+	AccountCreateEventType     Type = 1
+	PostEventType              Type = 2
+	CommentEventType           Type = 3
+	VoteEventType              Type = 4
+	FlagEventType              Type = 5
+	DeleteCommentEventType     Type = 6
+	CreateGameEventType        Type = 7
+	CancelGameEventType        Type = 8
+	UpdateGameStartEventType   Type = 9
+	PostGameResultsEventType   Type = 10
+	PostBetEventType           Type = 11
+	CancelPendingBetsEventType Type = 12
+	BetsMatchedEventType       Type = 13
+	GameStatusChangedEventType Type = 14
+	BetResolvedEventType       Type = 15
+	BetCancelledEventType      Type = 16
+	TransferEventType          Type = 17
+	CreateNFTEventType         Type = 18
+	UpdateNFTMetadataEventType Type = 19
+	// Deprecated: IncreaseNFTPowerEventType is not produced by any operation, it is kept so its number is not reused
+	IncreaseNFTPowerEventType                     Type = 20
+	BurnEventType                                 Type = 21
+	HardforkEventType                             Type = 22
+	HardforkVersionChangedEventType               Type = 23
+	MajorityVersionChangedEventType               Type = 24
+	ChainPropertiesChangedEventType               Type = 25
+	TransferToScorumpowerEventType                Type = 26
+	WithdrawScorumpowerEventType                  Type = 27
+	DelegateScorumpowerEventType                  Type = 28
+	DelegateSPFromRegPoolEventType                Type = 29
+	SetWithdrawScorumpowerRouteToAccountEventType Type = 30
+	SetWithdrawScorumpowerRouteToDevPoolEventType Type = 31
+	FillScorumpowerWithdrawEventType              Type = 32
+	ReturnScorumpowerDelegationEventType          Type = 33
+	WitnessUpdateEventType                        Type = 34
+	AccountWitnessVoteEventType                   Type = 35
+	AccountWitnessProxyEventType                  Type = 36
+	ProposalCreateEventType                       Type = 37
+	ProposalVoteEventType                         Type = 38
+	ProposalVirtualEventType                      Type = 39
+	ShutdownWitnessEventType                      Type = 40
+	WitnessMissBlockEventType                     Type = 41
+	AccountUpdateEventType                        Type = 42
+	RequestAccountRecoveryEventType               Type = 43
+	RecoverAccountEventType                       Type = 44
+	ChangeRecoveryAccountEventType                Type = 45
+	DeclineVotingRightsEventType                  Type = 46
+	ProveAuthorityEventType                       Type = 47
+	EscrowTransferEventType                       Type = 48
+	EscrowApproveEventType                        Type = 49
+	EscrowDisputeEventType                        Type = 50
+	EscrowReleaseEventType                        Type = 51
+	AtomicSwapInitiateEventType                   Type = 52
+	AtomicSwapRedeemEventType                     Type = 53
+	AtomicSwapRefundEventType                     Type = 54
+	ExpiredContractRefundEventType                Type = 55
+	CreateBudgetEventType                         Type = 56
+	UpdateBudgetEventType                         Type = 57
+	CloseBudgetEventType                          Type = 58
+	CloseBudgetByAdvertisingModeratorEventType    Type = 59
+	AllocateCashFromAdvertisingBudgetEventType    Type = 60
+	CashBackFromAdvertisingBudgetToOwnerEventType Type = 61
+	ClosingBudgetEventType                        Type = 62
+	CommentOptionsEventType                       Type = 63
+	AuthorRewardEventType                         Type = 64
+	CommentRewardEventType                        Type = 65
+	CurationRewardEventType                       Type = 66
+	CommentBenefactorRewardEventType              Type = 67
+	CommentPayoutUpdateEventType                  Type = 68
+	ActiveSPHoldersRewardLegacyEventType          Type = 69
+	CreateGameRoundEventType                      Type = 70
+	UpdateGameRoundResultEventType                Type = 71
+	AdjustNFTExperienceEventType                  Type = 72
+	UpdateNFTNameEventType                        Type = 73
+	UpdateGameMarketsEventType                    Type = 74
+	BetRestoredEventType                          Type = 75
+	BetUpdatedEventType                           Type = 76
+	VoteCastEventType                             Type = 77
+	VoteChangedEventType                          Type = 78
+	VoteRemovedEventType                          Type = 79
+	FlagCastEventType                             Type = 80
+	FlagRemovedEventType                          Type = 81
+	PostEditedEventType                           Type = 82
+	CommentEditedEventType                        Type = 83
 )
+
+// FirstCustomType is the first type allocated by NewType, built-in types stay below it
+const FirstCustomType Type = 1 << 16
+
+var lastCustomType = int64(FirstCustomType - 1)
+
+// NewType allocates a type for the events of custom converters
+func NewType() Type {
+	return Type(atomic.AddInt64(&lastCustomType, 1))
+}
+
+// typeInfo is the name of a type and the Go type its events are decoded into
+type typeInfo struct {
+	name      string
+	prototype reflect.Type
+}
+
+// builtinTypes are the names of the built-in types, the names are part of the JSON schema and must not be changed
+var builtinTypes = []struct {
+	eventType Type
+	name      string
+	prototype Event
+}{
+	{UnknownEventType, "unknown", UnknownEvent{}},
+	{AccountCreateEventType, "account_create", &AccountCreateEvent{}},
+	{PostEventType, "post", &PostEvent{}},
+	{CommentEventType, "comment", &CommentEvent{}},
+	{VoteEventType, "vote", &VoteEvent{}},
+	{FlagEventType, "flag", &FlagEvent{}},
+	{DeleteCommentEventType, "delete_comment", &DeleteCommentEvent{}},
+	{CreateGameEventType, "create_game", CreateGameEvent{}},
+	{CancelGameEventType, "cancel_game", CancelGameEvent{}},
+	{UpdateGameStartEventType, "update_game_start", UpdateGameStartTimeEvent{}},
+	{PostGameResultsEventType, "post_game_results", PostGameResultsEvent{}},
+	{PostBetEventType, "post_bet", PostBetEvent{}},
+	{CancelPendingBetsEventType, "cancel_pending_bets", CancelPendingBetEvent{}},
+	{BetsMatchedEventType, "bets_matched", BetsMatchedEvent{}},
+	{GameStatusChangedEventType, "game_status_changed", GameStatusChangedEvent{}},
+	{BetResolvedEventType, "bet_resolved", BetResolvedEvent{}},
+	{BetCancelledEventType, "bet_cancelled", BetCancelledEvent{}},
+	{TransferEventType, "transfer", TransferEvent{}},
+	{CreateNFTEventType, "create_nft", CreateNFTEvent{}},
+	{UpdateNFTMetadataEventType, "update_nft_metadata", UpdateNFTMetadataEvent{}},
+	{IncreaseNFTPowerEventType, "increase_nft_power", nil},
+	{BurnEventType, "burn", BurnEvent{}},
+	{HardforkEventType, "hardfork", HardforkEvent{}},
+	{HardforkVersionChangedEventType, "hardfork_version_changed", &HardforkVersionChangedEvent{}},
+	{MajorityVersionChangedEventType, "majority_version_changed", &MajorityVersionChangedEvent{}},
+	{ChainPropertiesChangedEventType, "chain_properties_changed", &ChainPropertiesChangedEvent{}},
+	{TransferToScorumpowerEventType, "transfer_to_scorumpower", &TransferToScorumpowerEvent{}},
+	{WithdrawScorumpowerEventType, "withdraw_scorumpower", &WithdrawScorumpowerEvent{}},
+	{DelegateScorumpowerEventType, "delegate_scorumpower", &DelegateScorumpowerEvent{}},
+	{DelegateSPFromRegPoolEventType, "delegate_sp_from_reg_pool", &DelegateSPFromRegPoolEvent{}},
+	{SetWithdrawScorumpowerRouteToAccountEventType, "set_withdraw_scorumpower_route_to_account", &SetWithdrawScorumpowerRouteToAccountEvent{}},
+	{SetWithdrawScorumpowerRouteToDevPoolEventType, "set_withdraw_scorumpower_route_to_dev_pool", &SetWithdrawScorumpowerRouteToDevPoolEvent{}},
+	{FillScorumpowerWithdrawEventType, "fill_scorumpower_withdraw", &FillScorumpowerWithdrawEvent{}},
+	{ReturnScorumpowerDelegationEventType, "return_scorumpower_delegation", &ReturnScorumpowerDelegationEvent{}},
+	{WitnessUpdateEventType, "witness_update", &WitnessUpdateEvent{}},
+	{AccountWitnessVoteEventType, "account_witness_vote", &AccountWitnessVoteEvent{}},
+	{AccountWitnessProxyEventType, "account_witness_proxy", &AccountWitnessProxyEvent{}},
+	{ProposalCreateEventType, "proposal_create", &ProposalCreateEvent{}},
+	{ProposalVoteEventType, "proposal_vote", &ProposalVoteEvent{}},
+	{ProposalVirtualEventType, "proposal_virtual", &ProposalVirtualEvent{}},
+	{ShutdownWitnessEventType, "shutdown_witness", &ShutdownWitnessEvent{}},
+	{WitnessMissBlockEventType, "witness_miss_block", &WitnessMissBlockEvent{}},
+	{AccountUpdateEventType, "account_update", &AccountUpdateEvent{}},
+	{RequestAccountRecoveryEventType, "request_account_recovery", &RequestAccountRecoveryEvent{}},
+	{RecoverAccountEventType, "recover_account", &RecoverAccountEvent{}},
+	{ChangeRecoveryAccountEventType, "change_recovery_account", &ChangeRecoveryAccountEvent{}},
+	{DeclineVotingRightsEventType, "decline_voting_rights", &DeclineVotingRightsEvent{}},
+	{ProveAuthorityEventType, "prove_authority", &ProveAuthorityEvent{}},
+	{EscrowTransferEventType, "escrow_transfer", &EscrowTransferEvent{}},
+	{EscrowApproveEventType, "escrow_approve", &EscrowApproveEvent{}},
+	{EscrowDisputeEventType, "escrow_dispute", &EscrowDisputeEvent{}},
+	{EscrowReleaseEventType, "escrow_release", &EscrowReleaseEvent{}},
+	{AtomicSwapInitiateEventType, "atomic_swap_initiate", &AtomicSwapInitiateEvent{}},
+	{AtomicSwapRedeemEventType, "atomic_swap_redeem", &AtomicSwapRedeemEvent{}},
+	{AtomicSwapRefundEventType, "atomic_swap_refund", &AtomicSwapRefundEvent{}},
+	{ExpiredContractRefundEventType, "expired_contract_refund", &ExpiredContractRefundEvent{}},
+	{CreateBudgetEventType, "create_budget", &CreateBudgetEvent{}},
+	{UpdateBudgetEventType, "update_budget", &UpdateBudgetEvent{}},
+	{CloseBudgetEventType, "close_budget", &CloseBudgetEvent{}},
+	{CloseBudgetByAdvertisingModeratorEventType, "close_budget_by_advertising_moderator", &CloseBudgetByAdvertisingModeratorEvent{}},
+	{AllocateCashFromAdvertisingBudgetEventType, "allocate_cash_from_advertising_budget", &AllocateCashFromAdvertisingBudgetEvent{}},
+	{CashBackFromAdvertisingBudgetToOwnerEventType, "cash_back_from_advertising_budget_to_owner", &CashBackFromAdvertisingBudgetToOwnerEvent{}},
+	{ClosingBudgetEventType, "closing_budget", &ClosingBudgetEvent{}},
+	{CommentOptionsEventType, "comment_options", &CommentOptionsEvent{}},
+	{AuthorRewardEventType, "author_reward", &AuthorRewardEvent{}},
+	{CommentRewardEventType, "comment_reward", &CommentRewardEvent{}},
+	{CurationRewardEventType, "curation_reward", &CurationRewardEvent{}},
+	{CommentBenefactorRewardEventType, "comment_benefactor_reward", &CommentBenefactorRewardEvent{}},
+	{CommentPayoutUpdateEventType, "comment_payout_update", &CommentPayoutUpdateEvent{}},
+	{ActiveSPHoldersRewardLegacyEventType, "active_sp_holders_reward_legacy", &ActiveSPHoldersRewardLegacyEvent{}},
+	{CreateGameRoundEventType, "create_game_round", CreateGameRoundEvent{}},
+	{UpdateGameRoundResultEventType, "update_game_round_result", UpdateGameRoundResultEvent{}},
+	{AdjustNFTExperienceEventType, "adjust_nft_experience", AdjustNFTExperienceEvent{}},
+	{UpdateNFTNameEventType, "update_nft_name", UpdateNFTNameEvent{}},
+	{UpdateGameMarketsEventType, "update_game_markets", UpdateGameMarketsEvent{}},
+	{BetRestoredEventType, "bet_restored", BetRestoredEvent{}},
+	{BetUpdatedEventType, "bet_updated", BetUpdatedEvent{}},
+	{VoteCastEventType, "vote_cast", &VoteCastEvent{}},
+	{VoteChangedEventType, "vote_changed", &VoteChangedEvent{}},
+	{VoteRemovedEventType, "vote_removed", &VoteRemovedEvent{}},
+	{FlagCastEventType, "flag_cast", &FlagCastEvent{}},
+	{FlagRemovedEventType, "flag_removed", &FlagRemovedEvent{}},
+	{PostEditedEventType, "post_edited", &PostEditedEvent{}},
+	{CommentEditedEventType, "comment_edited", &CommentEditedEvent{}},
+}
+
+var eventTypes = struct {
+	sync.RWMutex
+	byType map[Type]typeInfo
+	byName map[string]Type
+}{
+	byType: make(map[Type]typeInfo),
+	byName: make(map[string]Type),
+}
+
+func init() {
+	for _, t := range builtinTypes {
+		info := typeInfo{name: t.name}
+		if t.prototype != nil {
+			info.prototype = reflect.TypeOf(t.prototype)
+		}
+
+		eventTypes.byType[t.eventType] = info
+		eventTypes.byName[t.name] = t.eventType
+	}
+}
+
+// RegisterType names the type of the prototype, so its events can be encoded by MarshalEvent and decoded by UnmarshalEvent.
+// The events are decoded into the Go type of the prototype, e.g. a pointer if the prototype is a pointer.
+func RegisterType(name string, prototype Event) error {
+	eventTypes.Lock()
+	defer eventTypes.Unlock()
+
+	if _, exists := eventTypes.byName[name]; exists {
+		return fmt.Errorf("%w: %s", ErrTypeRegistered, name)
+	}
+
+	if _, exists := eventTypes.byType[prototype.Type()]; exists {
+		return fmt.Errorf("%w: %d", ErrTypeRegistered, prototype.Type())
+	}
+
+	eventTypes.byType[prototype.Type()] = typeInfo{name: name, prototype: reflect.TypeOf(prototype)}
+	eventTypes.byName[name] = prototype.Type()
+
+	return nil
+}
+
+// NewEvent returns an empty event of the type in the Go form its converter produces, e.g. *VoteEvent or CreateGameEvent
+func NewEvent(t Type) (Event, error) {
+	eventTypes.RLock()
+	info, exists := eventTypes.byType[t]
+	eventTypes.RUnlock()
+
+	if !exists || info.prototype == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, t)
+	}
+
+	if info.prototype.Kind() == reflect.Ptr {
+		return reflect.New(info.prototype.Elem()).Interface().(Event), nil
+	}
+
+	return reflect.Zero(info.prototype).Interface().(Event), nil
+}
+
+// String returns the name of the type, or Type(n) for a custom type without a name
+func (t Type) String() string {
+	eventTypes.RLock()
+	info, exists := eventTypes.byType[t]
+	eventTypes.RUnlock()
+
+	if !exists {
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+
+	return info.name
+}
+
+// MarshalText encodes the type by its name, or by its number for a custom type without a name
+func (t Type) MarshalText() ([]byte, error) {
+	eventTypes.RLock()
+	info, exists := eventTypes.byType[t]
+	eventTypes.RUnlock()
+
+	if !exists {
+		return []byte(strconv.Itoa(int(t))), nil
+	}
+
+	return []byte(info.name), nil
+}
+
+// UnmarshalText decodes the type from its name or its number
+func (t *Type) UnmarshalText(text []byte) error {
+	if n, err := strconv.Atoi(string(text)); err == nil {
+		*t = Type(n)
+		return nil
+	}
+
+	parsed, err := ParseType(string(text))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// ParseType returns the type of the name, e.g. "post_bet"
+func ParseType(name string) (Type, error) {
+	eventTypes.RLock()
+	t, exists := eventTypes.byName[name]
+	eventTypes.RUnlock()
+
+	if !exists {
+		return UnknownEventType, fmt.Errorf("%w: %s", ErrUnknownEventType, name)
+	}
+
+	return t, nil
+}
+
+// Types returns the sorted built-in types and the custom types named with RegisterType
+func Types() []Type {
+	eventTypes.RLock()
+	defer eventTypes.RUnlock()
+
+	types := make([]Type, 0, len(eventTypes.byType))
+	for t := range eventTypes.byType {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
@@ -0,0 +1,185 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestType_Stable pins the numbers and the names of the built-in types, both are persisted by consumers
+func TestType_Stable(t *testing.T) {
+	cases := []struct {
+		eventType Type
+		value     int
+		name      string
+	}{
+		{UnknownEventType, 0, "unknown"},
+		{AccountCreateEventType, 1, "account_create"},
+		{PostEventType, 2, "post"},
+		{CommentEventType, 3, "comment"},
+		{VoteEventType, 4, "vote"},
+		{FlagEventType, 5, "flag"},
+		{DeleteCommentEventType, 6, "delete_comment"},
+		{CreateGameEventType, 7, "create_game"},
+		{CancelGameEventType, 8, "cancel_game"},
+		{UpdateGameStartEventType, 9, "update_game_start"},
+		{PostGameResultsEventType, 10, "post_game_results"},
+		{PostBetEventType, 11, "post_bet"},
+		{CancelPendingBetsEventType, 12, "cancel_pending_bets"},
+		{BetsMatchedEventType, 13, "bets_matched"},
+		{GameStatusChangedEventType, 14, "game_status_changed"},
+		{BetResolvedEventType, 15, "bet_resolved"},
+		{BetCancelledEventType, 16, "bet_cancelled"},
+		{TransferEventType, 17, "transfer"},
+		{CreateNFTEventType, 18, "create_nft"},
+		{UpdateNFTMetadataEventType, 19, "update_nft_metadata"},
+		{IncreaseNFTPowerEventType, 20, "increase_nft_power"},
+		{BurnEventType, 21, "burn"},
+		{HardforkEventType, 22, "hardfork"},
+		{HardforkVersionChangedEventType, 23, "hardfork_version_changed"},
+		{MajorityVersionChangedEventType, 24, "majority_version_changed"},
+		{ChainPropertiesChangedEventType, 25, "chain_properties_changed"},
+		{TransferToScorumpowerEventType, 26, "transfer_to_scorumpower"},
+		{WithdrawScorumpowerEventType, 27, "withdraw_scorumpower"},
+		{DelegateScorumpowerEventType, 28, "delegate_scorumpower"},
+		{DelegateSPFromRegPoolEventType, 29, "delegate_sp_from_reg_pool"},
+		{SetWithdrawScorumpowerRouteToAccountEventType, 30, "set_withdraw_scorumpower_route_to_account"},
+		{SetWithdrawScorumpowerRouteToDevPoolEventType, 31, "set_withdraw_scorumpower_route_to_dev_pool"},
+		{FillScorumpowerWithdrawEventType, 32, "fill_scorumpower_withdraw"},
+		{ReturnScorumpowerDelegationEventType, 33, "return_scorumpower_delegation"},
+		{WitnessUpdateEventType, 34, "witness_update"},
+		{AccountWitnessVoteEventType, 35, "account_witness_vote"},
+		{AccountWitnessProxyEventType, 36, "account_witness_proxy"},
+		{ProposalCreateEventType, 37, "proposal_create"},
+		{ProposalVoteEventType, 38, "proposal_vote"},
+		{ProposalVirtualEventType, 39, "proposal_virtual"},
+		{ShutdownWitnessEventType, 40, "shutdown_witness"},
+		{WitnessMissBlockEventType, 41, "witness_miss_block"},
+		{AccountUpdateEventType, 42, "account_update"},
+		{RequestAccountRecoveryEventType, 43, "request_account_recovery"},
+		{RecoverAccountEventType, 44, "recover_account"},
+		{ChangeRecoveryAccountEventType, 45, "change_recovery_account"},
+		{DeclineVotingRightsEventType, 46, "decline_voting_rights"},
+		{ProveAuthorityEventType, 47, "prove_authority"},
+		{EscrowTransferEventType, 48, "escrow_transfer"},
+		{EscrowApproveEventType, 49, "escrow_approve"},
+		{EscrowDisputeEventType, 50, "escrow_dispute"},
+		{EscrowReleaseEventType, 51, "escrow_release"},
+		{AtomicSwapInitiateEventType, 52, "atomic_swap_initiate"},
+		{AtomicSwapRedeemEventType, 53, "atomic_swap_redeem"},
+		{AtomicSwapRefundEventType, 54, "atomic_swap_refund"},
+		{ExpiredContractRefundEventType, 55, "expired_contract_refund"},
+		{CreateBudgetEventType, 56, "create_budget"},
+		{UpdateBudgetEventType, 57, "update_budget"},
+		{CloseBudgetEventType, 58, "close_budget"},
+		{CloseBudgetByAdvertisingModeratorEventType, 59, "close_budget_by_advertising_moderator"},
+		{AllocateCashFromAdvertisingBudgetEventType, 60, "allocate_cash_from_advertising_budget"},
+		{CashBackFromAdvertisingBudgetToOwnerEventType, 61, "cash_back_from_advertising_budget_to_owner"},
+		{ClosingBudgetEventType, 62, "closing_budget"},
+		{CommentOptionsEventType, 63, "comment_options"},
+		{AuthorRewardEventType, 64, "author_reward"},
+		{CommentRewardEventType, 65, "comment_reward"},
+		{CurationRewardEventType, 66, "curation_reward"},
+		{CommentBenefactorRewardEventType, 67, "comment_benefactor_reward"},
+		{CommentPayoutUpdateEventType, 68, "comment_payout_update"},
+		{ActiveSPHoldersRewardLegacyEventType, 69, "active_sp_holders_reward_legacy"},
+		{CreateGameRoundEventType, 70, "create_game_round"},
+		{UpdateGameRoundResultEventType, 71, "update_game_round_result"},
+		{AdjustNFTExperienceEventType, 72, "adjust_nft_experience"},
+		{UpdateNFTNameEventType, 73, "update_nft_name"},
+		{UpdateGameMarketsEventType, 74, "update_game_markets"},
+		{BetRestoredEventType, 75, "bet_restored"},
+		{BetUpdatedEventType, 76, "bet_updated"},
+		{VoteCastEventType, 77, "vote_cast"},
+		{VoteChangedEventType, 78, "vote_changed"},
+		{VoteRemovedEventType, 79, "vote_removed"},
+		{FlagCastEventType, 80, "flag_cast"},
+		{FlagRemovedEventType, 81, "flag_removed"},
+		{PostEditedEventType, 82, "post_edited"},
+		{CommentEditedEventType, 83, "comment_edited"},
+	}
+
+	require.Len(t, cases, len(builtinTypes))
+	for _, c := range cases {
+		require.Equal(t, c.value, int(c.eventType), c.name)
+		require.Equal(t, c.name, c.eventType.String())
+
+		parsed, err := ParseType(c.name)
+		require.NoError(t, err)
+		require.Equal(t, c.eventType, parsed)
+	}
+}
+
+func TestType_Text(t *testing.T) {
+	var config struct {
+		Types []Type `json:"types"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"types": ["post_bet", "bet_cancelled"]}`), &config))
+	require.Equal(t, []Type{PostBetEventType, BetCancelledEventType}, config.Types)
+
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"types": ["post_bet", "bet_cancelled"]}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"types": ["no_such_type"]}`), &config)
+	require.True(t, errors.Is(err, ErrUnknownEventType))
+
+	unnamed := NewType()
+	require.Equal(t, fmt.Sprintf("Type(%d)", int(unnamed)), unnamed.String())
+
+	// a type without a name is encoded by its number, both forms are decoded
+	config.Types = []Type{PostBetEventType, unnamed}
+	data, err = json.Marshal(config)
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"types": ["post_bet", "%d"]}`, int(unnamed)), string(data))
+
+	config.Types = nil
+	require.NoError(t, json.Unmarshal(data, &config))
+	require.Equal(t, []Type{PostBetEventType, unnamed}, config.Types)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"types": ["11", "bet_cancelled"]}`), &config))
+	require.Equal(t, []Type{PostBetEventType, BetCancelledEventType}, config.Types)
+
+	_, err = ParseType("Type(1)")
+	require.True(t, errors.Is(err, ErrUnknownEventType))
+}
+
+func TestTypes(t *testing.T) {
+	types := Types()
+	for _, bt := range builtinTypes {
+		require.Contains(t, types, bt.eventType)
+	}
+	require.Contains(t, types, customJSONEventType)
+	require.Equal(t, "custom_json", customJSONEventType.String())
+
+	for i := 1; i < len(types); i++ {
+		require.Less(t, types[i-1], types[i])
+	}
+}
+
+func TestCategories(t *testing.T) {
+	categorized := make(map[Type]bool)
+	for _, c := range Categories() {
+		require.NotEmpty(t, c.Types(), c)
+		for _, eventType := range c.Types() {
+			categorized[eventType] = true
+		}
+	}
+
+	// every event produced by the provider is in a category
+	for _, bt := range builtinTypes {
+		if bt.prototype != nil && bt.eventType != UnknownEventType {
+			require.True(t, categorized[bt.eventType], "%s is not in any category", bt.name)
+		}
+	}
+
+	require.Empty(t, Category("no_such_category").Types())
+
+	types := TypesOf(SocialCategory, FinanceCategory)
+	require.Contains(t, types, VoteEventType)
+	require.Contains(t, types, TransferEventType)
+	require.Len(t, types, len(SocialCategory.Types())+len(FinanceCategory.Types())-4, "content rewards are both social and finance")
+}
@@ -0,0 +1,67 @@
+package event
+
+// VoteCastEvent is produced by projection.Votes for a vote on content the voter had not voted on
+type VoteCastEvent struct {
+	Voter          string `json:"voter"`
+	Author         string `json:"author"`
+	PermLink       string `json:"permlink"`
+	PreviousWeight int16  `json:"previous_weight"`
+	Weight         int16  `json:"weight"`
+}
+
+func (e VoteCastEvent) Type() Type {
+	return VoteCastEventType
+}
+
+// VoteChangedEvent is produced by projection.Votes when the weight of a vote changes
+type VoteChangedEvent struct {
+	Voter          string `json:"voter"`
+	Author         string `json:"author"`
+	PermLink       string `json:"permlink"`
+	PreviousWeight int16  `json:"previous_weight"`
+	Weight         int16  `json:"weight"`
+}
+
+func (e VoteChangedEvent) Type() Type {
+	return VoteChangedEventType
+}
+
+// VoteRemovedEvent is produced by projection.Votes when a vote is removed by an unvote or replaced by a flag
+type VoteRemovedEvent struct {
+	Voter          string `json:"voter"`
+	Author         string `json:"author"`
+	PermLink       string `json:"permlink"`
+	PreviousWeight int16  `json:"previous_weight"`
+	Weight         int16  `json:"weight"`
+}
+
+func (e VoteRemovedEvent) Type() Type {
+	return VoteRemovedEventType
+}
+
+// FlagCastEvent is produced by projection.Votes for a flag, PreviousWeight is negative when the weight of a flag changes
+// and positive when a vote is replaced by the flag
+type FlagCastEvent struct {
+	Voter          string `json:"voter"`
+	Author         string `json:"author"`
+	PermLink       string `json:"permlink"`
+	PreviousWeight int16  `json:"previous_weight"`
+	Weight         int16  `json:"weight"`
+}
+
+func (e FlagCastEvent) Type() Type {
+	return FlagCastEventType
+}
+
+// FlagRemovedEvent is produced by projection.Votes when a flag is removed by an unvote or replaced by a vote
+type FlagRemovedEvent struct {
+	Voter          string `json:"voter"`
+	Author         string `json:"author"`
+	PermLink       string `json:"permlink"`
+	PreviousWeight int16  `json:"previous_weight"`
+	Weight         int16  `json:"weight"`
+}
+
+func (e FlagRemovedEvent) Type() Type {
+	return FlagRemovedEventType
+}
@@ -0,0 +1,214 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// WitnessUpdateEvent
+type WitnessUpdateEvent struct {
+	Owner              string `json:"owner"`
+	URL                string `json:"url"`
+	BlockSigningKey    string `json:"block_signing_key"`
+	AccountCreationFee Asset  `json:"account_creation_fee"`
+	MaximumBlockSize   int32  `json:"maximum_block_size"`
+}
+
+func (e WitnessUpdateEvent) Type() Type {
+	return WitnessUpdateEventType
+}
+
+func toWitnessUpdateEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.WitnessUpdateOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	fee, err := parseAsset(v.Props.AccountCreationFee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WitnessUpdateEvent{
+		Owner:              v.Owner,
+		URL:                v.Url,
+		BlockSigningKey:    v.BlockSigningKey,
+		AccountCreationFee: fee,
+		MaximumBlockSize:   v.Props.MaximumBlockSize,
+	}, nil
+}
+
+// AccountWitnessVoteEvent, Approve is false when the vote is removed
+type AccountWitnessVoteEvent struct {
+	Account string `json:"account"`
+	Witness string `json:"witness"`
+	Approve bool   `json:"approve"`
+}
+
+func (e AccountWitnessVoteEvent) Type() Type {
+	return AccountWitnessVoteEventType
+}
+
+func toAccountWitnessVoteEvent(op types.Operation) (Event, error) {
+	v, ok := op.(*types.AccountWitnessVoteOperation)
+	if !ok {
+		return nil, ErrWrongOperationType
+	}
+
+	return &AccountWitnessVoteEvent{
+		Account: v.Account,
+		Witness: v.Witness,
+		Approve: v.Approve,
+	}, nil
+}
+
+// AccountWitnessProxyEvent, an empty Proxy clears the proxy
+type AccountWitnessProxyEvent struct {
+	Account string `json:"account"`
+	Proxy   string `json:"proxy"`
+}
+
+func (e AccountWitnessProxyEvent) Type() Type {
+	return AccountWitnessProxyEventType
+}
+
+func toAccountWitnessProxyEvent(op types.Operation) (Event, error) {
+	var e AccountWitnessProxyEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ProposalOperation is the operation a proposal applies once it is accepted,
+// e.g. registration_committee_add_member
+type ProposalOperation struct {
+	Name string
+	Data json.RawMessage
+}
+
+// UnmarshalJSON decodes the operation from the [name, data] form
+func (o *ProposalOperation) UnmarshalJSON(b []byte) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(b, &tuple); err != nil {
+		return err
+	}
+
+	if len(tuple) != 2 {
+		return errors.New("invalid proposal operation format: should be name, value")
+	}
+
+	if err := json.Unmarshal(tuple[0], &o.Name); err != nil {
+		return err
+	}
+	o.Data = tuple[1]
+
+	return nil
+}
+
+// MarshalJSON encodes the operation in the [name, data] form
+func (o ProposalOperation) MarshalJSON() ([]byte, error) {
+	data := o.Data
+	if data == nil {
+		data = json.RawMessage("{}")
+	}
+
+	return json.Marshal([]interface{}{o.Name, data})
+}
+
+// ProposalCreateEvent
+type ProposalCreateEvent struct {
+	Creator     string            `json:"creator"`
+	LifetimeSec uint32            `json:"lifetime_sec"`
+	Operation   ProposalOperation `json:"operation"`
+}
+
+func (e ProposalCreateEvent) Type() Type {
+	return ProposalCreateEventType
+}
+
+func toProposalCreateEvent(op types.Operation) (Event, error) {
+	var e ProposalCreateEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ProposalVoteEvent
+type ProposalVoteEvent struct {
+	VotingAccount string `json:"voting_account"`
+	ProposalID    int64  `json:"proposal_id"`
+}
+
+func (e ProposalVoteEvent) Type() Type {
+	return ProposalVoteEventType
+}
+
+func toProposalVoteEvent(op types.Operation) (Event, error) {
+	var e ProposalVoteEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ProposalVirtualEvent is produced by the proposal_virtual virtual operation when an accepted proposal is applied
+type ProposalVirtualEvent struct {
+	Operation ProposalOperation `json:"proposal_op"`
+}
+
+func (e ProposalVirtualEvent) Type() Type {
+	return ProposalVirtualEventType
+}
+
+func toProposalVirtualEvent(op types.Operation) (Event, error) {
+	var e ProposalVirtualEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ShutdownWitnessEvent is produced by the shutdown_witness virtual operation
+type ShutdownWitnessEvent struct {
+	Owner string `json:"owner"`
+}
+
+func (e ShutdownWitnessEvent) Type() Type {
+	return ShutdownWitnessEventType
+}
+
+func toShutdownWitnessEvent(op types.Operation) (Event, error) {
+	var e ShutdownWitnessEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// WitnessMissBlockEvent is produced by the witness_miss_block virtual operation
+type WitnessMissBlockEvent struct {
+	Owner    string `json:"owner"`
+	BlockNum uint32 `json:"block_num"`
+}
+
+func (e WitnessMissBlockEvent) Type() Type {
+	return WitnessMissBlockEventType
+}
+
+func toWitnessMissBlockEvent(op types.Operation) (Event, error) {
+	var e WitnessMissBlockEvent
+	if err := unmarshalUnknownOperation(op, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
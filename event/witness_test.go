@@ -0,0 +1,113 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+func TestWitnessEvents(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "witness update",
+			converter: toWitnessUpdateEvent,
+			op: &types.WitnessUpdateOperation{
+				Owner:           "alice",
+				Url:             "https://alice.example",
+				BlockSigningKey: "SCR1111111111111111111111111111111114T1Anm",
+				Props:           types.WitnessUpdateOperationProps{AccountCreationFee: "0.750000000 SCR", MaximumBlockSize: 65536},
+			},
+			expected: &WitnessUpdateEvent{
+				Owner:              "alice",
+				URL:                "https://alice.example",
+				BlockSigningKey:    "SCR1111111111111111111111111111111114T1Anm",
+				AccountCreationFee: mustParseAsset(t, "0.750000000 SCR"),
+				MaximumBlockSize:   65536,
+			},
+		},
+		{
+			name:      "account witness vote",
+			converter: toAccountWitnessVoteEvent,
+			op:        &types.AccountWitnessVoteOperation{Account: "alice", Witness: "bob", Approve: true},
+			expected:  &AccountWitnessVoteEvent{Account: "alice", Witness: "bob", Approve: true},
+		},
+		{
+			name:      "account witness proxy",
+			converter: toAccountWitnessProxyEvent,
+			op:        rawOperation(`{"account": "alice", "proxy": "bob"}`),
+			expected:  &AccountWitnessProxyEvent{Account: "alice", Proxy: "bob"},
+		},
+		{
+			name:      "account witness proxy cleared",
+			converter: toAccountWitnessProxyEvent,
+			op:        rawOperation(`{"account": "alice"}`),
+			expected:  &AccountWitnessProxyEvent{Account: "alice"},
+		},
+		{
+			name:      "proposal create",
+			converter: toProposalCreateEvent,
+			op:        rawOperation(`{"creator": "alice", "lifetime_sec": 86400, "operation": ["registration_committee_add_member", {"account_name": "bob"}]}`),
+			expected: &ProposalCreateEvent{
+				Creator:     "alice",
+				LifetimeSec: 86400,
+				Operation:   ProposalOperation{Name: "registration_committee_add_member", Data: json.RawMessage(`{"account_name": "bob"}`)},
+			},
+		},
+		{
+			name:      "proposal vote",
+			converter: toProposalVoteEvent,
+			op:        rawOperation(`{"voting_account": "alice", "proposal_id": 7}`),
+			expected:  &ProposalVoteEvent{VotingAccount: "alice", ProposalID: 7},
+		},
+		{
+			name:      "proposal virtual",
+			converter: toProposalVirtualEvent,
+			op:        rawOperation(`{"proposal_op": ["development_committee_change_quorum", {"quorum": 60}]}`),
+			expected: &ProposalVirtualEvent{
+				Operation: ProposalOperation{Name: "development_committee_change_quorum", Data: json.RawMessage(`{"quorum": 60}`)},
+			},
+		},
+		{
+			name:      "shutdown witness",
+			converter: toShutdownWitnessEvent,
+			op:        rawOperation(`{"owner": "alice"}`),
+			expected:  &ShutdownWitnessEvent{Owner: "alice"},
+		},
+		{
+			name:      "witness miss block",
+			converter: toWitnessMissBlockEvent,
+			op:        rawOperation(`{"owner": "alice", "block_num": 100}`),
+			expected:  &WitnessMissBlockEvent{Owner: "alice", BlockNum: 100},
+		},
+	})
+}
+
+func TestWitnessEvents_Errors(t *testing.T) {
+	testConverters(t, []converterCase{
+		{
+			name:      "proposal operation without data",
+			converter: toProposalVirtualEvent,
+			op:        rawOperation(`{"proposal_op": ["development_committee_change_quorum"]}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "malformed proposal id",
+			converter: toProposalVoteEvent,
+			op:        rawOperation(`{"voting_account": "alice", "proposal_id": "seven"}`),
+			err:       ErrMalformedOperation,
+		},
+		{
+			name:      "raw operation of a scorum-go operation",
+			converter: toAccountWitnessVoteEvent,
+			op:        rawOperation(`{"account": "alice", "witness": "bob", "approve": true}`),
+			err:       ErrWrongOperationType,
+		},
+		{
+			name:      "scorum-go operation of a raw operation",
+			converter: toShutdownWitnessEvent,
+			op:        &types.AccountWitnessVoteOperation{Account: "alice", Witness: "bob"},
+			err:       ErrWrongOperationType,
+		},
+	})
+}
@@ -0,0 +1,544 @@
+// Package eventpb encodes blocks and events in protobuf, the messages are described by event.proto.
+// The schema is derived from the event structs, so it follows the events without generated code.
+package eventpb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	ErrUnsupportedEvent = errors.New("event has no protobuf message")
+	ErrMalformedMessage = errors.New("malformed protobuf message")
+)
+
+const (
+	blockNumField  protowire.Number = 1
+	timestampField protowire.Number = 2
+	eventsField    protowire.Number = 3
+)
+
+// Marshal encodes the block as a Block message
+func Marshal(block event.Block) ([]byte, error) {
+	var b []byte
+	if block.BlockNum != 0 {
+		b = protowire.AppendTag(b, blockNumField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(block.BlockNum))
+	}
+	if !block.Timestamp.IsZero() {
+		b = protowire.AppendTag(b, timestampField, protowire.BytesType)
+		b = protowire.AppendBytes(b, appendTimestamp(nil, block.Timestamp))
+	}
+
+	for _, e := range block.Events {
+		data, err := MarshalEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, eventsField, protowire.BytesType)
+		b = protowire.AppendBytes(b, data)
+	}
+
+	return b, nil
+}
+
+// Unmarshal decodes a Block message
+func Unmarshal(b []byte) (event.Block, error) {
+	var block event.Block
+
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == blockNumField && typ == protowire.VarintType:
+			block.BlockNum = uint32(varint)
+		case num == timestampField && typ == protowire.BytesType:
+			t, err := consumeTimestamp(value)
+			if err != nil {
+				return err
+			}
+			block.Timestamp = t
+		case num == eventsField && typ == protowire.BytesType:
+			e, err := UnmarshalEvent(value)
+			if err != nil {
+				return err
+			}
+			block.Events = append(block.Events, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return event.Block{}, err
+	}
+
+	return block, nil
+}
+
+// MarshalEvent encodes the event as an Event message, custom events have no message and return ErrUnsupportedEvent
+func MarshalEvent(e event.Event) ([]byte, error) {
+	if e == nil || reflect.ValueOf(e).Kind() == reflect.Ptr && reflect.ValueOf(e).IsNil() {
+		return nil, errors.New("can't marshal nil event")
+	}
+
+	m, ok := defaultSchema.events[e.Type()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, e.Type())
+	}
+
+	v := reflect.ValueOf(e)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Type() != m.goType {
+		return nil, fmt.Errorf("%w: %s is %s", ErrUnsupportedEvent, e.Type(), v.Type())
+	}
+
+	data, err := appendMessage(nil, m, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.Type(), err)
+	}
+
+	b := protowire.AppendTag(nil, fieldNumber(e.Type()), protowire.BytesType)
+	return protowire.AppendBytes(b, data), nil
+}
+
+// UnmarshalEvent decodes an Event message into the event of its type
+func UnmarshalEvent(b []byte) (event.Event, error) {
+	var e event.Event
+
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		if typ != protowire.BytesType || num < 1 {
+			return nil
+		}
+
+		t := event.Type(num - 1)
+		m, ok := defaultSchema.events[t]
+		if !ok {
+			return nil
+		}
+
+		decoded, err := event.NewEvent(t)
+		if err != nil {
+			return err
+		}
+
+		v := reflect.ValueOf(&decoded).Elem().Elem()
+		if v.Kind() == reflect.Ptr {
+			if err := consumeFields(value, m, v.Elem()); err != nil {
+				return fmt.Errorf("%s: %w", t, err)
+			}
+		} else {
+			// events kept by value are decoded into a copy, the interface value isn't addressable
+			ptr := reflect.New(v.Type())
+			if err := consumeFields(value, m, ptr.Elem()); err != nil {
+				return fmt.Errorf("%s: %w", t, err)
+			}
+			decoded = ptr.Elem().Interface().(event.Event)
+		}
+
+		e = decoded
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if e == nil {
+		return nil, fmt.Errorf("%w: no known event", ErrMalformedMessage)
+	}
+
+	return e, nil
+}
+
+func appendMessage(b []byte, m *message, v reflect.Value) ([]byte, error) {
+	for _, f := range m.fields {
+		fv := v.FieldByIndex(f.index)
+
+		if !f.repeated {
+			var err error
+			if b, err = appendValue(b, f, fv, true); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			continue
+		}
+
+		for i := 0; i < fv.Len(); i++ {
+			var err error
+			if b, err = appendValue(b, f, fv.Index(i), false); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// appendValue appends the field with the value, zero values are skipped unless they are list elements
+func appendValue(b []byte, f field, v reflect.Value, skipZero bool) ([]byte, error) {
+	if skipZero && v.IsZero() && f.kind != gameTypeKind {
+		return b, nil
+	}
+
+	switch f.kind {
+	case stringKind:
+		return appendString(b, f.number, v.String()), nil
+	case jsonKind:
+		return appendString(b, f.number, string(v.Bytes())), nil
+	case uuidKind:
+		return appendString(b, f.number, v.Interface().(uuid.UUID).String()), nil
+	case gameTypeKind:
+		return appendString(b, f.number, types.GameTypeNames[types.GameType(v.Uint())]), nil
+	case boolKind:
+		b = protowire.AppendTag(b, f.number, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeBool(v.Bool())), nil
+	case int32Kind, int64Kind:
+		b = protowire.AppendTag(b, f.number, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(v.Int())), nil
+	case uint32Kind, uint64Kind:
+		b = protowire.AppendTag(b, f.number, protowire.VarintType)
+		return protowire.AppendVarint(b, v.Uint()), nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch f.kind {
+	case timestampKind:
+		t, ok := toTime(v)
+		if !ok {
+			return b, nil
+		}
+		data = appendTimestamp(nil, t)
+	case assetKind:
+		data = appendAsset(nil, v)
+	case wincaseKind:
+		w := v.Interface().(types.Wincase)
+		if w.WincaseInterface == nil {
+			return nil, errors.New("wincase is not set")
+		}
+		data, err = appendOutcome(nil, w.GetName(), w.GetMeta)
+	case marketKind:
+		m := v.Interface().(types.Market)
+		if m.MarketInterface == nil {
+			return nil, errors.New("market is not set")
+		}
+		data, err = appendOutcome(nil, m.GetName(), m.GetMeta)
+	case messageKind:
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return b, nil
+			}
+			v = v.Elem()
+		}
+		data, err = appendMessage(nil, f.message, v)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b = protowire.AppendTag(b, f.number, protowire.BytesType)
+	return protowire.AppendBytes(b, data), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func toTime(v reflect.Value) (time.Time, bool) {
+	switch t := v.Interface().(type) {
+	case time.Time:
+		return t, !t.IsZero()
+	case types.Time:
+		if t.Time == nil {
+			return time.Time{}, false
+		}
+		return *t.Time, true
+	}
+	return time.Time{}, false
+}
+
+// appendTimestamp appends the fields of google.protobuf.Timestamp
+func appendTimestamp(b []byte, t time.Time) []byte {
+	if seconds := t.Unix(); seconds != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(seconds))
+	}
+	if nanos := t.Nanosecond(); nanos != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(nanos))
+	}
+	return b
+}
+
+func appendAsset(b []byte, v reflect.Value) []byte {
+	var amount, symbol string
+	switch a := v.Interface().(type) {
+	case types.Asset:
+		amount, symbol = a.Decimal().StringFixed(9), types.Symbol
+	case event.Asset:
+		amount, symbol = a.Decimal().StringFixed(9), a.Symbol
+	}
+
+	b = appendString(b, 1, amount)
+	if symbol != "" {
+		b = appendString(b, 2, symbol)
+	}
+	return b
+}
+
+// outcomeMeta is the meta of a wincase or a market, the fields absent in the meta are nil
+type outcomeMeta struct {
+	Threshold *int16  `json:"threshold,omitempty"`
+	Home      *uint16 `json:"home,omitempty"`
+	Away      *uint16 `json:"away,omitempty"`
+}
+
+// appendOutcome appends the fields of a Wincase or a Market message
+func appendOutcome(b []byte, name string, getMeta func() (json.RawMessage, error)) ([]byte, error) {
+	raw, err := getMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	var meta outcomeMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+
+	b = appendString(b, 1, name)
+	if meta.Threshold != nil {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(*meta.Threshold)))
+	}
+	if meta.Home != nil {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(*meta.Home))
+	}
+	if meta.Away != nil {
+		b = protowire.AppendTag(b, 4, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(*meta.Away))
+	}
+	return b, nil
+}
+
+// consumeMessage calls fn for every field of the message, value is set for length-delimited fields and varint for varints
+func consumeMessage(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("%w: %s", ErrMalformedMessage, protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		var (
+			value  []byte
+			varint uint64
+		)
+		switch typ {
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return fmt.Errorf("%w: %s", ErrMalformedMessage, protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		if err := fn(num, typ, value, varint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func consumeFields(b []byte, m *message, v reflect.Value) error {
+	fields := make(map[protowire.Number]field, len(m.fields))
+	for _, f := range m.fields {
+		fields[f.number] = f
+	}
+
+	return consumeMessage(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		f, ok := fields[num]
+		if !ok {
+			return nil
+		}
+
+		want := protowire.BytesType
+		switch f.kind {
+		case boolKind, int32Kind, int64Kind, uint32Kind, uint64Kind:
+			want = protowire.VarintType
+		}
+		if typ != want {
+			return fmt.Errorf("%w: %s has wire type %d", ErrMalformedMessage, f.name, typ)
+		}
+
+		fv := v.FieldByIndex(f.index)
+		if !f.repeated {
+			if err := setValue(fv, f, value, varint); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+			return nil
+		}
+
+		elem := reflect.New(fv.Type().Elem()).Elem()
+		if err := setValue(elem, f, value, varint); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		fv.Set(reflect.Append(fv, elem))
+		return nil
+	})
+}
+
+func setValue(v reflect.Value, f field, value []byte, varint uint64) error {
+	switch f.kind {
+	case stringKind:
+		v.SetString(string(value))
+	case jsonKind:
+		v.SetBytes(append(json.RawMessage(nil), value...))
+	case uuidKind:
+		id, err := uuid.ParseBytes(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(id))
+	case gameTypeKind:
+		for gameType, name := range types.GameTypeNames {
+			if name == string(value) {
+				v.SetUint(uint64(gameType))
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown game type %s", value)
+	case boolKind:
+		v.SetBool(protowire.DecodeBool(varint))
+	case int32Kind, int64Kind:
+		if v.OverflowInt(int64(varint)) {
+			return fmt.Errorf("%d overflows %s", int64(varint), v.Type())
+		}
+		v.SetInt(int64(varint))
+	case uint32Kind, uint64Kind:
+		if v.OverflowUint(varint) {
+			return fmt.Errorf("%d overflows %s", varint, v.Type())
+		}
+		v.SetUint(varint)
+	case timestampKind:
+		t, err := consumeTimestamp(value)
+		if err != nil {
+			return err
+		}
+		if v.Type() == chainTime {
+			v.Set(reflect.ValueOf(types.Time{Time: &t}))
+		} else {
+			v.Set(reflect.ValueOf(t))
+		}
+	case assetKind:
+		return setAsset(v, value)
+	case wincaseKind, marketKind:
+		raw, err := consumeOutcome(value)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(raw, v.Addr().Interface())
+	case messageKind:
+		if v.Kind() == reflect.Ptr {
+			v.Set(reflect.New(v.Type().Elem()))
+			v = v.Elem()
+		}
+		return consumeFields(value, f.message, v)
+	}
+
+	return nil
+}
+
+func consumeTimestamp(b []byte) (time.Time, error) {
+	var seconds, nanos int64
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, _ []byte, varint uint64) error {
+		if typ != protowire.VarintType {
+			return nil
+		}
+		switch num {
+		case 1:
+			seconds = int64(varint)
+		case 2:
+			nanos = int64(int32(varint))
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, nanos).UTC(), nil
+}
+
+func setAsset(v reflect.Value, b []byte) error {
+	var amount, symbol string
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			amount = string(value)
+		case 2:
+			symbol = string(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	a, err := types.AssetFromString(amount)
+	if err != nil {
+		return err
+	}
+
+	if v.Type() == eventAsset {
+		v.Set(reflect.ValueOf(event.Asset{Asset: *a, Symbol: symbol}))
+	} else {
+		v.Set(reflect.ValueOf(*a))
+	}
+	return nil
+}
+
+// consumeOutcome decodes a Wincase or a Market message into the [name, meta] form of the node
+func consumeOutcome(b []byte) ([]byte, error) {
+	var (
+		name string
+		meta outcomeMeta
+	)
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			name = string(value)
+		case num == 2 && typ == protowire.VarintType:
+			threshold := int16(varint)
+			meta.Threshold = &threshold
+		case num == 3 && typ == protowire.VarintType:
+			home := uint16(varint)
+			meta.Home = &home
+		case num == 4 && typ == protowire.VarintType:
+			away := uint16(varint)
+			meta.Away = &away
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal([]interface{}{name, meta})
+}
@@ -0,0 +1,349 @@
+package eventpb
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var update = flag.Bool("update", false, "update event.proto")
+
+const key = "SCR5jPZF7PMgTpLqkdfpMu8kXea8Gio6E646aYpTgcjr9qMLrAgnL"
+
+// sampleOperations cover the values with a special encoding: assets, times, uuids, wincases, markets, odds and nested messages
+var sampleOperations = []struct {
+	opType types.OpType
+	data   string
+}{
+	{types.CreateGame, `{"uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f", "moderator": "alice", "json_metadata": "{}", "game": ["hockey_game", {}],
+		"start_time": "2019-06-01T18:00:00", "auto_resolve_delay_sec": 86400,
+		"markets": [["result_home", {}], ["total", {"threshold": -500}], ["correct_score", {"home": 0, "away": 2}]]}`},
+	{types.PostGameResults, `{"uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f", "moderator": "alice",
+		"wincases": [["result_home::yes", {}], ["total::over", {"threshold": 0}], ["correct_score::yes", {"home": 1, "away": 0}]]}`},
+	{types.PostBet, `{"uuid": "a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e", "better": "bob", "game_uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f",
+		"wincase": ["total::under", {"threshold": 2500}], "odds": {"numerator": 3, "denominator": 2}, "stake": "1.000000000 SCR", "live": true}`},
+	{types.CancelPendingBets, `{"bet_uuids": ["a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e", "00000000-0000-0000-0000-000000000000"], "better": "bob"}`},
+	{types.TransferOpType, `{"from": "alice", "to": "bob", "amount": "1.000000000 SCR", "memo": "memo"}`},
+	{types.WithdrawScorumpowerOpType, `{"account": "alice", "scorumpower": "1.500000000 SP"}`},
+	{types.AccountUpdateOpType, `{"account": "alice", "owner": {"weight_threshold": 1, "account_auths": [["bob", 1]], "key_auths": [["` + key + `", 1]]},
+		"memo_key": "` + key + `", "json_metadata": "{}"}`},
+	{types.ProposalCreateOperation, `{"creator": "alice", "lifetime_sec": 86400, "operation": ["registration_committee_add_member", {"account_name":"bob"}]}`},
+	{types.EscrowTransfer, `{"from": "alice", "to": "bob", "agent": "carol", "escrow_id": 7, "scorum_amount": "10.000000000 SCR", "fee": "0.100000000 SCR",
+		"ratification_deadline": "2018-08-01T10:00:00", "escrow_expiration": "2018-08-10T10:00:00", "json_meta": "{}"}`},
+	{types.CreateBudget, `{"type": "banner", "uuid": "e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f", "owner": "alice", "json_metadata": "{}",
+		"balance": "10.000000000 SCR", "start": "2019-01-01T00:00:00", "deadline": "2019-02-01T00:00:00"}`},
+	{types.VoteOpType, `{"voter": "bob", "author": "alice", "permlink": "post", "weight": -10000}`},
+}
+
+func sampleEvents(t *testing.T) []event.Event {
+	var events []event.Event
+	for _, sample := range sampleOperations {
+		var ops types.OperationsFlat
+		require.NoError(t, json.Unmarshal([]byte(`["`+string(sample.opType)+`", `+sample.data+`]`), &ops))
+		require.Len(t, ops, 1)
+
+		e, err := event.ToEvent(ops[0])
+		require.NoError(t, err, sample.opType)
+		events = append(events, e)
+	}
+	return events
+}
+
+// TestSchema_File checks event.proto, -update regenerates it unless a field number of the checked-in file is changed
+func TestSchema_File(t *testing.T) {
+	b, err := os.ReadFile("event.proto")
+	require.NoError(t, err)
+	require.NoError(t, checkNumbersKept(string(b), Schema()))
+
+	if *update {
+		require.NoError(t, os.WriteFile("event.proto", []byte(Schema()), 0644))
+		return
+	}
+
+	require.Equal(t, string(b), Schema(), "event.proto is outdated, run go test ./eventpb -update")
+}
+
+func TestSchema_NumbersKept(t *testing.T) {
+	b, err := os.ReadFile("event.proto")
+	require.NoError(t, err)
+	proto := string(b)
+
+	renumbered := strings.Replace(proto, "  Asset amount = 7;\n}\n\nmessage AtomicSwapInitiate", "  Asset amount = 8;\n}\n\nmessage AtomicSwapInitiate", 1)
+	require.NotEqual(t, proto, renumbered)
+	require.Error(t, checkNumbersKept(proto, renumbered))
+
+	removed := strings.Replace(proto, "  Asset amount = 7;\n}\n\nmessage AtomicSwapInitiate", "  reserved 7;\n}\n\nmessage AtomicSwapInitiate", 1)
+	require.NoError(t, checkNumbersKept(proto, removed))
+
+	reused := strings.Replace(removed, "  reserved 7;\n", "  reserved 7;\n  string memo = 8;\n", 1)
+	require.NoError(t, checkNumbersKept(removed, reused))
+	require.Error(t, checkNumbersKept(removed, strings.Replace(removed, "  reserved 7;\n", "  string memo = 7;\n", 1)))
+}
+
+func TestSchema_FieldNumbers(t *testing.T) {
+	numbers := fieldNumbers["EscrowRelease"]
+	defer func() { fieldNumbers["EscrowRelease"] = numbers }()
+
+	changed := func(change func(map[string]protowire.Number)) map[string]protowire.Number {
+		m := make(map[string]protowire.Number, len(numbers))
+		for name, number := range numbers {
+			m[name] = number
+		}
+		change(m)
+		return m
+	}
+
+	fieldNumbers["EscrowRelease"] = changed(func(m map[string]protowire.Number) { delete(m, "amount") })
+	_, err := buildSchema()
+	require.Error(t, err, "field without a number")
+
+	fieldNumbers["EscrowRelease"] = changed(func(m map[string]protowire.Number) { m["amount"] = m["escrow_id"] })
+	_, err = buildSchema()
+	require.Error(t, err, "number used twice")
+
+	fieldNumbers["EscrowRelease"] = changed(func(m map[string]protowire.Number) { m["scorum_amount"] = 8 })
+	_, err = buildSchema()
+	require.Error(t, err, "removed field not reserved")
+}
+
+// TestSchema_Numbers checks that the checked-in event.proto, fieldNumbers and the encoded messages agree on the numbers
+func TestSchema_Numbers(t *testing.T) {
+	b, err := os.ReadFile("event.proto")
+	require.NoError(t, err)
+	proto := protoNumbers(string(b))
+
+	for name := range proto {
+		switch name {
+		case "Block", "Event", "Asset", "Wincase", "Market":
+		default:
+			require.Contains(t, fieldNumbers, name, "message %s of event.proto has no field numbers", name)
+		}
+	}
+
+	for name, numbers := range fieldNumbers {
+		fields, exists := proto[name]
+		require.True(t, exists, "message %s is not in event.proto", name)
+		require.Len(t, fields, len(numbers)+len(reservedNumbers[name]), name)
+
+		for field, number := range numbers {
+			require.Equal(t, field, fields[int(number)], "%s.%s", name, field)
+		}
+		for _, number := range reservedNumbers[name] {
+			field, exists := fields[int(number)]
+			require.True(t, exists && field == "", "%s: %d is not reserved", name, number)
+		}
+	}
+
+	for typ := range defaultSchema.events {
+		require.Equal(t, typ.String(), proto["Event"][int(fieldNumber(typ))], typ)
+	}
+
+	for _, e := range sampleEvents(t) {
+		b, err := MarshalEvent(e)
+		require.NoError(t, err)
+
+		num, typ, n := protowire.ConsumeTag(b)
+		require.Equal(t, protowire.BytesType, typ)
+		require.Equal(t, e.Type().String(), proto["Event"][int(num)])
+
+		data, n2 := protowire.ConsumeBytes(b[n:])
+		require.Equal(t, len(b), n+n2)
+		requireEncodedNumbers(t, proto, defaultSchema.events[e.Type()], data)
+	}
+}
+
+// requireEncodedNumbers checks that every field of the encoded message has its number in event.proto and in fieldNumbers
+func requireEncodedNumbers(t *testing.T, proto map[string]map[int]string, m *message, b []byte) {
+	require.NoError(t, consumeMessage(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		name := proto[m.name][int(num)]
+		require.NotEmpty(t, name, "%s: field %d is not in event.proto", m.name, num)
+		require.Equal(t, num, fieldNumbers[m.name][name], "%s.%s", m.name, name)
+
+		f := m.field(name)
+		require.NotNil(t, f, "%s.%s", m.name, name)
+		if f.kind == messageKind {
+			requireEncodedNumbers(t, proto, f.message, value)
+		}
+		return nil
+	}))
+}
+
+var (
+	messageLine  = regexp.MustCompile(`^message (\w+) \{$`)
+	fieldLine    = regexp.MustCompile(`^\s+(?:repeated |optional )?[\w.]+ (\w+) = (\d+);$`)
+	reservedLine = regexp.MustCompile(`^\s+reserved (\d+);$`)
+)
+
+// protoNumbers returns the numbers of the fields of every message of the .proto file, the reserved numbers have no name
+func protoNumbers(proto string) map[string]map[int]string {
+	messages := make(map[string]map[int]string)
+
+	var numbers map[int]string
+	for _, line := range strings.Split(proto, "\n") {
+		if m := messageLine.FindStringSubmatch(line); m != nil {
+			numbers = make(map[int]string)
+			messages[m[1]] = numbers
+		} else if m := fieldLine.FindStringSubmatch(line); m != nil && numbers != nil {
+			number, _ := strconv.Atoi(m[2])
+			numbers[number] = m[1]
+		} else if m := reservedLine.FindStringSubmatch(line); m != nil && numbers != nil {
+			number, _ := strconv.Atoi(m[1])
+			numbers[number] = ""
+		}
+	}
+
+	return messages
+}
+
+// checkNumbersKept returns an error if a number of a message of the old .proto file is not used or reserved
+// by the message in the new one, if a field is renumbered, or if a reserved number is used again
+func checkNumbersKept(oldProto, newProto string) error {
+	newMessages := protoNumbers(newProto)
+	for name, oldNumbers := range protoNumbers(oldProto) {
+		newNumbers, exists := newMessages[name]
+		if !exists {
+			continue
+		}
+
+		newFields := make(map[string]int)
+		for number, field := range newNumbers {
+			newFields[field] = number
+		}
+
+		for number, field := range oldNumbers {
+			newField, exists := newNumbers[number]
+			switch {
+			case !exists:
+				return fmt.Errorf("%s: number %d of %s is neither used nor reserved", name, number, field)
+			case field == "" && newField != "":
+				return fmt.Errorf("%s: reserved number %d is used by %s", name, number, newField)
+			}
+
+			if newNumber, exists := newFields[field]; field != "" && exists && newNumber != number {
+				return fmt.Errorf("%s: field %s is renumbered from %d to %d", name, field, number, newNumber)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestSchema_BuiltinTypes(t *testing.T) {
+	for _, typ := range event.Types() {
+		if typ >= event.FirstCustomType {
+			continue
+		}
+
+		_, err := event.NewEvent(typ)
+		_, mapped := defaultSchema.events[typ]
+		require.Equal(t, err == nil, mapped, typ)
+	}
+}
+
+func TestMarshalEvent_RoundTrip(t *testing.T) {
+	for _, e := range sampleEvents(t) {
+		b, err := MarshalEvent(e)
+		require.NoError(t, err, e.Type())
+
+		decoded, err := UnmarshalEvent(b)
+		require.NoError(t, err, e.Type())
+		require.Equal(t, e, decoded)
+	}
+}
+
+func TestMarshalEvent_Empty(t *testing.T) {
+	for typ := range defaultSchema.events {
+		e, err := event.NewEvent(typ)
+		require.NoError(t, err)
+
+		b, err := MarshalEvent(e)
+		require.NoError(t, err, typ)
+
+		decoded, err := UnmarshalEvent(b)
+		require.NoError(t, err, typ)
+		require.Equal(t, e, decoded, typ)
+	}
+}
+
+type customEvent struct{}
+
+func (e customEvent) Type() event.Type {
+	return event.FirstCustomType + 100
+}
+
+func TestMarshalEvent_Unsupported(t *testing.T) {
+	_, err := MarshalEvent(customEvent{})
+	require.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	_, err = MarshalEvent(nil)
+	require.Error(t, err)
+
+	_, err = MarshalEvent((*event.VoteEvent)(nil))
+	require.Error(t, err)
+}
+
+func TestUnmarshalEvent_Errors(t *testing.T) {
+	_, err := UnmarshalEvent([]byte{0xff})
+	require.ErrorIs(t, err, ErrMalformedMessage)
+
+	// an event of a type added after the reader was built
+	unknown := protowire.AppendTag(nil, 10000, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, nil)
+	_, err = UnmarshalEvent(unknown)
+	require.ErrorIs(t, err, ErrMalformedMessage)
+}
+
+func TestMarshal_Block(t *testing.T) {
+	block := event.Block{
+		BlockNum:  100,
+		Timestamp: time.Date(2019, 6, 1, 18, 0, 0, 0, time.UTC),
+		Events:    sampleEvents(t),
+	}
+
+	b, err := Marshal(block)
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(b)
+	require.NoError(t, err)
+	require.Equal(t, block, decoded)
+
+	empty, err := Marshal(event.Block{})
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	decoded, err = Unmarshal(empty)
+	require.NoError(t, err)
+	require.Equal(t, event.Block{}, decoded)
+}
+
+// assets are the amounts every converter parses, the other fields of an operation may be absent
+const assets = `{"amount": "1.000000000 SCR", "scorumpower": "1.000000000 SP", "max_accepted_payout": "1.000000000 SP",
+	"props": {"account_creation_fee": "1.000000000 SCR"}}`
+
+func TestConverters_Mapped(t *testing.T) {
+	for _, opType := range event.RegisteredOpTypes() {
+		var ops types.OperationsFlat
+		require.NoError(t, json.Unmarshal([]byte(`["`+string(opType)+`", `+assets+`]`), &ops))
+
+		e, err := event.ToEvent(ops[0])
+		require.NoError(t, err, opType)
+
+		b, err := MarshalEvent(e)
+		require.NoError(t, err, opType)
+
+		decoded, err := UnmarshalEvent(b)
+		require.NoError(t, err, opType)
+		require.Equal(t, e, decoded, opType)
+	}
+}
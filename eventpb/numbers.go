@@ -0,0 +1,124 @@
+package eventpb
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// fieldNumbers are the numbers of the fields of the messages by message and field name. Consumers decode the fields
+// by number, so a number never changes: a new field takes the next free number of its message
+// and the number of a removed field is moved to reservedNumbers.
+var fieldNumbers = map[string]map[string]protowire.Number{
+	"AccountAuth":   {"account": 1, "weight": 2},
+	"AccountCreate": {"account": 1},
+	"AccountUpdate": {
+		"account": 1, "owner": 2, "active": 3, "posting": 4, "memo_key": 5, "json_metadata": 6, "previous_owner": 7,
+		"previous_active": 8, "previous_posting": 9, "previous_memo_key": 10,
+	},
+	"AccountWitnessProxy":               {"account": 1, "proxy": 2},
+	"AccountWitnessVote":                {"account": 1, "witness": 2, "approve": 3},
+	"ActiveSpHoldersRewardLegacy":       {"sp_holder": 1, "reward": 2},
+	"AdjustNftExperience":               {"moderator": 1, "uuid": 2, "experience": 3},
+	"AllocateCashFromAdvertisingBudget": {"type": 1, "uuid": 2, "owner": 3, "cash": 4},
+	"AtomicSwapInitiate":                {"type": 1, "owner": 2, "recipient": 3, "amount": 4, "secret_hash": 5, "metadata": 6, "deadline": 7},
+	"AtomicSwapRedeem":                  {"from": 1, "to": 2, "secret": 3},
+	"AtomicSwapRefund":                  {"participant": 1, "initiator": 2, "secret_hash": 3},
+	"AuthorReward":                      {"author": 1, "permlink": 2, "reward": 3},
+	"Authority":                         {"weight_threshold": 1, "account_auths": 2, "key_auths": 3},
+	"Beneficiary":                       {"account": 1, "weight": 2},
+	"BetCancelled":                      {"game_uuid": 1, "better": 2, "bet_uuid": 3, "stake": 4, "kind": 5},
+	"BetResolved":                       {"game_uuid": 1, "better": 2, "bet_uuid": 3, "income": 4, "kind": 5},
+	"BetRestored":                       {"game_uuid": 1, "better": 2, "bet_uuid": 3, "stake": 4},
+	"BetUpdated":                        {"game_uuid": 1, "better": 2, "bet_uuid": 3, "kind": 4, "old_stake": 5, "new_stake": 6},
+	"BetsMatched": {
+		"bet1_uuid": 1, "bet2_uuid": 2, "better1": 3, "better2": 4, "matched_stake1": 5, "matched_stake2": 6, "matched_bet_id": 7,
+	},
+	"Burn":                                 {"owner": 1, "to": 2, "amount": 3},
+	"CancelGame":                           {"uuid": 1, "moderator": 2},
+	"CancelPendingBets":                    {"bet_uuids": 1, "better": 2},
+	"CashBackFromAdvertisingBudgetToOwner": {"type": 1, "uuid": 2, "owner": 3, "cash": 4},
+	"ChainProperties":                      {"account_creation_fee": 1, "maximum_block_size": 2},
+	"ChainPropertiesChanged":               {"old": 1, "new": 2},
+	"ChangeRecoveryAccount":                {"account_to_recover": 1, "new_recovery_account": 2},
+	"CloseBudget":                          {"type": 1, "uuid": 2, "owner": 3},
+	"CloseBudgetByAdvertisingModerator":    {"type": 1, "uuid": 2, "moderator": 3},
+	"ClosingBudget":                        {"type": 1, "uuid": 2, "owner": 3},
+	"Comment":                              {"permlink": 1, "parent_author": 2, "parent_permlink": 3, "author": 4, "body": 5, "json_metadata": 6, "title": 7},
+	"CommentBenefactorReward":              {"benefactor": 1, "author": 2, "permlink": 3, "reward": 4},
+	"CommentEdited": {
+		"author": 1, "permlink": 2, "parent_author": 3, "parent_permlink": 4, "previous_title": 5, "title": 6, "previous_body": 7,
+		"body": 8, "patch": 9, "previous_json_metadata": 10, "json_metadata": 11, "patch_error": 12,
+	},
+	"CommentOptions": {
+		"author": 1, "permlink": 2, "max_accepted_payout": 3, "percent_scrs": 4, "allow_votes": 5, "allow_curation_rewards": 6,
+		"beneficiaries": 7,
+	},
+	"CommentPayoutUpdate": {"author": 1, "permlink": 2},
+	"CommentReward": {
+		"author": 1, "permlink": 2, "fund_reward": 3, "total_payout": 4, "author_payout": 5, "curators_payout": 6,
+		"beneficiaries_payout": 7,
+	},
+	"CreateBudget": {"type": 1, "uuid": 2, "owner": 3, "json_metadata": 4, "balance": 5, "start": 6, "deadline": 7},
+	"CreateGame": {
+		"uuid": 1, "moderator": 2, "json_metadata": 3, "game": 4, "start_time": 5, "auto_resolve_delay_sec": 6, "markets": 7,
+	},
+	"CreateGameRound":       {"owner": 1, "uuid": 2, "verification_key": 3, "seed": 4},
+	"CreateNft":             {"owner": 1, "uuid": 2, "name": 3, "json_metadata": 4, "initial_power": 5},
+	"CurationReward":        {"curator": 1, "reward": 2, "author": 3, "permlink": 4},
+	"DeclineVotingRights":   {"account": 1, "decline": 2},
+	"DelegateScorumpower":   {"delegator": 1, "delegatee": 2, "scorumpower": 3},
+	"DelegateSpFromRegPool": {"reg_committee_member": 1, "delegatee": 2, "scorumpower": 3},
+	"DeleteComment":         {"permlink": 1, "author": 2},
+	"EscrowApprove":         {"from": 1, "to": 2, "agent": 3, "who": 4, "escrow_id": 5, "approve": 6},
+	"EscrowDispute":         {"from": 1, "to": 2, "agent": 3, "who": 4, "escrow_id": 5},
+	"EscrowRelease":         {"from": 1, "to": 2, "agent": 3, "who": 4, "receiver": 5, "escrow_id": 6, "amount": 7},
+	"EscrowTransfer": {
+		"from": 1, "to": 2, "agent": 3, "escrow_id": 4, "amount": 5, "fee": 6, "ratification_deadline": 7, "escrow_expiration": 8,
+		"json_metadata": 9,
+	},
+	"ExpiredContractRefund":   {"owner": 1, "refund": 2},
+	"FillScorumpowerWithdraw": {"from_account": 1, "to_account": 2, "withdrawn": 3, "deposited": 4},
+	"Flag":                    {"voter": 1, "author": 2, "permlink": 3, "weight": 4},
+	"FlagCast":                {"voter": 1, "author": 2, "permlink": 3, "previous_weight": 4, "weight": 5},
+	"FlagRemoved":             {"voter": 1, "author": 2, "permlink": 3, "previous_weight": 4, "weight": 5},
+	"GameStatusChanged":       {"game_uuid": 1, "old_status": 2, "new_status": 3},
+	"Hardfork":                {"hardfork_id": 1},
+	"HardforkVersionChanged":  {"old_version": 1, "new_version": 2},
+	"KeyAuth":                 {"key": 1, "weight": 2},
+	"MajorityVersionChanged":  {"old_version": 1, "new_version": 2},
+	"Odds":                    {"numerator": 1, "denominator": 2},
+	"Post":                    {"permlink": 1, "parent_permlink": 2, "author": 3, "body": 4, "json_metadata": 5, "title": 6},
+	"PostBet":                 {"uuid": 1, "better": 2, "game_uuid": 3, "wincase": 4, "odds": 5, "stake": 6, "live": 7},
+	"PostEdited": {
+		"author": 1, "permlink": 2, "parent_permlink": 3, "previous_title": 4, "title": 5, "previous_body": 6, "body": 7,
+		"patch": 8, "previous_json_metadata": 9, "json_metadata": 10, "patch_error": 11,
+	},
+	"PostGameResults":                      {"uuid": 1, "moderator": 2, "wincases": 3},
+	"ProposalCreate":                       {"creator": 1, "lifetime_sec": 2, "operation": 3},
+	"ProposalOperation":                    {"name": 1, "data": 2},
+	"ProposalVirtual":                      {"proposal_op": 1},
+	"ProposalVote":                         {"voting_account": 1, "proposal_id": 2},
+	"ProveAuthority":                       {"challenged": 1, "require_owner": 2},
+	"RecoverAccount":                       {"account_to_recover": 1, "new_owner_authority": 2, "recent_owner_authority": 3},
+	"RequestAccountRecovery":               {"recovery_account": 1, "account_to_recover": 2, "new_owner_authority": 3},
+	"ReturnScorumpowerDelegation":          {"account": 1, "scorumpower": 2},
+	"SetWithdrawScorumpowerRouteToAccount": {"from_account": 1, "to_account": 2, "percent": 3, "auto_vest": 4},
+	"SetWithdrawScorumpowerRouteToDevPool": {"from_account": 1, "percent": 2, "auto_vest": 3},
+	"ShutdownWitness":                      {"owner": 1},
+	"Transfer":                             {"from": 1, "to": 2, "amount": 3, "memo": 4},
+	"TransferToScorumpower":                {"from": 1, "to": 2, "amount": 3},
+	"Unknown":                              {"op_type": 1, "data": 2},
+	"UpdateBudget":                         {"type": 1, "uuid": 2, "owner": 3, "json_metadata": 4},
+	"UpdateGameMarkets":                    {"uuid": 1, "moderator": 2, "markets": 3},
+	"UpdateGameRoundResult":                {"owner": 1, "uuid": 2, "proof": 3, "vrf": 4, "result": 5},
+	"UpdateGameStart":                      {"uuid": 1, "moderator": 2, "start_time": 3},
+	"UpdateNftMetadata":                    {"moderator": 1, "uuid": 2, "json_metadata": 3},
+	"UpdateNftName":                        {"moderator": 1, "uuid": 2, "name": 3},
+	"Vote":                                 {"voter": 1, "author": 2, "permlink": 3, "weight": 4},
+	"VoteCast":                             {"voter": 1, "author": 2, "permlink": 3, "previous_weight": 4, "weight": 5},
+	"VoteChanged":                          {"voter": 1, "author": 2, "permlink": 3, "previous_weight": 4, "weight": 5},
+	"VoteRemoved":                          {"voter": 1, "author": 2, "permlink": 3, "previous_weight": 4, "weight": 5},
+	"WithdrawScorumpower":                  {"account": 1, "scorumpower": 2},
+	"WitnessMissBlock":                     {"owner": 1, "block_num": 2},
+	"WitnessUpdate":                        {"owner": 1, "url": 2, "block_signing_key": 3, "account_creation_fee": 4, "maximum_block_size": 5},
+}
+
+// reservedNumbers are the numbers of the removed fields by message name, they are never used again
+var reservedNumbers = map[string][]protowire.Number{}
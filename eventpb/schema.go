@@ -0,0 +1,482 @@
+package eventpb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// kind is how a Go value is represented in protobuf
+type kind int
+
+const (
+	stringKind kind = iota
+	boolKind
+	int32Kind
+	int64Kind
+	uint32Kind
+	uint64Kind
+	uuidKind
+	jsonKind
+	gameTypeKind
+	timestampKind
+	assetKind
+	wincaseKind
+	marketKind
+	messageKind
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	chainTime    = reflect.TypeOf(types.Time{})
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	rawJSONType  = reflect.TypeOf(json.RawMessage{})
+	gameType     = reflect.TypeOf(types.GameType(0))
+	chainAsset   = reflect.TypeOf(types.Asset{})
+	eventAsset   = reflect.TypeOf(event.Asset{})
+	wincaseType  = reflect.TypeOf(types.Wincase{})
+	marketType   = reflect.TypeOf(types.Market{})
+	eventsHeader = `// Code generated by eventpb, DO NOT EDIT.
+// Run go test ./eventpb -update to regenerate it after an event is changed.`
+)
+
+// field is a field of a message, its number is pinned in fieldNumbers
+type field struct {
+	name     string
+	number   protowire.Number
+	index    []int
+	kind     kind
+	repeated bool
+	message  *message
+}
+
+// message describes the protobuf message of a Go struct, the fields are sorted by number
+type message struct {
+	name     string
+	goType   reflect.Type
+	fields   []field
+	reserved []protowire.Number
+}
+
+// schema describes the messages of all built-in events
+type schema struct {
+	// events are the messages of the built-in types, the field of a type in the Event oneof is the type number + 1
+	events map[event.Type]*message
+	// reserved are the Event fields of the built-in types without events
+	reserved []protowire.Number
+	// nested are the messages of the structs used by the events
+	nested map[reflect.Type]*message
+}
+
+var defaultSchema = mustBuildSchema()
+
+func mustBuildSchema() *schema {
+	s, err := buildSchema()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func buildSchema() (*schema, error) {
+	s := &schema{
+		events: make(map[event.Type]*message),
+		nested: make(map[reflect.Type]*message),
+	}
+
+	names := make(map[string]reflect.Type)
+	for _, name := range []string{"Block", "Event", "Asset", "Wincase", "Market"} {
+		names[name] = nil
+	}
+
+	for _, t := range event.Types() {
+		if t >= event.FirstCustomType {
+			continue
+		}
+
+		e, err := event.NewEvent(t)
+		if err != nil {
+			s.reserved = append(s.reserved, fieldNumber(t))
+			continue
+		}
+
+		goType := reflect.TypeOf(e)
+		if goType.Kind() == reflect.Ptr {
+			goType = goType.Elem()
+		}
+
+		m, err := s.newMessage(messageName(t.String()), goType, names)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		s.events[t] = m
+	}
+
+	for name := range fieldNumbers {
+		if _, exists := names[name]; !exists {
+			return nil, fmt.Errorf("message %s of fieldNumbers is not used by any event", name)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *schema) newMessage(name string, goType reflect.Type, names map[string]reflect.Type) (*message, error) {
+	if other, exists := names[name]; exists && other != goType {
+		return nil, fmt.Errorf("message name %s is used twice", name)
+	}
+	names[name] = goType
+
+	numbers, exists := fieldNumbers[name]
+	if !exists {
+		return nil, fmt.Errorf("message %s has no field numbers", name)
+	}
+
+	m := &message{name: name, goType: goType, reserved: reservedNumbers[name]}
+
+	var walk func(t reflect.Type, index []int) error
+	walk = func(t reflect.Type, index []int) error {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fieldIndex := append(append([]int(nil), index...), i)
+
+			if f.Anonymous && f.Type.Kind() == reflect.Struct && !isSpecial(f.Type) {
+				if err := walk(f.Type, fieldIndex); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if f.PkgPath != "" {
+				continue
+			}
+
+			name := fieldName(f)
+			if name == "-" {
+				continue
+			}
+
+			goType, repeated := f.Type, false
+			if goType.Kind() == reflect.Slice && goType != rawJSONType {
+				goType, repeated = goType.Elem(), true
+			}
+
+			k, err := kindOf(goType)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+
+			if repeated && !(k == stringKind || k == uuidKind || k >= timestampKind) {
+				return fmt.Errorf("field %s: repeated scalars are not supported", f.Name)
+			}
+
+			number, exists := numbers[name]
+			if !exists {
+				return fmt.Errorf("field %s of %s has no number in fieldNumbers", name, m.name)
+			}
+
+			fld := field{
+				name:     name,
+				number:   number,
+				index:    fieldIndex,
+				kind:     k,
+				repeated: repeated,
+			}
+
+			if k == messageKind {
+				structType := goType
+				if structType.Kind() == reflect.Ptr {
+					structType = structType.Elem()
+				}
+
+				nested, exists := s.nested[structType]
+				if !exists {
+					if nested, err = s.newMessage(structType.Name(), structType, names); err != nil {
+						return err
+					}
+					s.nested[structType] = nested
+				}
+				fld.message = nested
+			}
+
+			m.fields = append(m.fields, fld)
+		}
+		return nil
+	}
+
+	if err := walk(goType, nil); err != nil {
+		return nil, err
+	}
+
+	if err := m.checkNumbers(numbers); err != nil {
+		return nil, fmt.Errorf("message %s: %w", name, err)
+	}
+
+	return m, nil
+}
+
+// checkNumbers checks that every numbered field exists and that the numbers are used once
+func (m *message) checkNumbers(numbers map[string]protowire.Number) error {
+	if len(numbers) != len(m.fields) {
+		for name := range numbers {
+			if m.field(name) == nil {
+				return fmt.Errorf("field %s is numbered but removed, move its number to reservedNumbers", name)
+			}
+		}
+	}
+
+	used := make(map[protowire.Number]string)
+	for _, number := range m.reserved {
+		used[number] = "reserved"
+	}
+	for _, f := range m.fields {
+		if !f.number.IsValid() {
+			return fmt.Errorf("field %s has invalid number %d", f.name, f.number)
+		}
+		if other, exists := used[f.number]; exists {
+			return fmt.Errorf("field %s has the number %d of %s", f.name, f.number, other)
+		}
+		used[f.number] = f.name
+	}
+
+	sort.Slice(m.fields, func(i, j int) bool { return m.fields[i].number < m.fields[j].number })
+	return nil
+}
+
+func (m *message) field(name string) *field {
+	for i := range m.fields {
+		if m.fields[i].name == name {
+			return &m.fields[i]
+		}
+	}
+	return nil
+}
+
+func isSpecial(t reflect.Type) bool {
+	switch t {
+	case timeType, chainTime, uuidType, chainAsset, eventAsset, wincaseType, marketType:
+		return true
+	}
+	return false
+}
+
+func kindOf(t reflect.Type) (kind, error) {
+	switch t {
+	case timeType, chainTime:
+		return timestampKind, nil
+	case uuidType:
+		return uuidKind, nil
+	case rawJSONType:
+		return jsonKind, nil
+	case gameType:
+		return gameTypeKind, nil
+	case chainAsset, eventAsset:
+		return assetKind, nil
+	case wincaseType:
+		return wincaseKind, nil
+	case marketType:
+		return marketKind, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return stringKind, nil
+	case reflect.Bool:
+		return boolKind, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return int32Kind, nil
+	case reflect.Int, reflect.Int64:
+		return int64Kind, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return uint32Kind, nil
+	case reflect.Uint, reflect.Uint64:
+		return uint64Kind, nil
+	case reflect.Struct:
+		return messageKind, nil
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.Struct && !isSpecial(t.Elem()) {
+			return messageKind, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported type %s", t)
+}
+
+func fieldName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return snakeCase(f.Name)
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prevLower := name[i-1] >= 'a' && name[i-1] <= 'z'
+			nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+			prevUpper := name[i-1] >= 'A' && name[i-1] <= 'Z'
+			if prevLower || (prevUpper && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		if upper {
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// messageName converts the name of a type, e.g. post_bet, into a message name, e.g. PostBet
+func messageName(typeName string) string {
+	parts := strings.Split(typeName, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func fieldNumber(t event.Type) protowire.Number {
+	return protowire.Number(t) + 1
+}
+
+func (k kind) protoType(m *message) string {
+	switch k {
+	case stringKind, uuidKind, jsonKind, gameTypeKind:
+		return "string"
+	case boolKind:
+		return "bool"
+	case int32Kind:
+		return "int32"
+	case int64Kind:
+		return "int64"
+	case uint32Kind:
+		return "uint32"
+	case uint64Kind:
+		return "uint64"
+	case timestampKind:
+		return "google.protobuf.Timestamp"
+	case assetKind:
+		return "Asset"
+	case wincaseKind:
+		return "Wincase"
+	case marketKind:
+		return "Market"
+	default:
+		return m.name
+	}
+}
+
+// Schema returns the .proto definitions of the messages written by Marshal, the same as event.proto
+func Schema() string {
+	return defaultSchema.render()
+}
+
+func (s *schema) render() string {
+	var b strings.Builder
+
+	b.WriteString(eventsHeader + `
+
+syntax = "proto3";
+
+package scorum.events.v1;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "github.com/scorum/event-provider-go/eventpb";
+option java_multiple_files = true;
+option java_package = "com.scorum.events.v1";
+
+message Block {
+  uint32 block_num = 1;
+  google.protobuf.Timestamp timestamp = 2;
+  repeated Event events = 3;
+}
+
+// Asset is an amount with 9 decimal places, e.g. "1.000000000" SCR
+message Asset {
+  string amount = 1;
+  string symbol = 2;
+}
+
+// Wincase is a wincase of a bet, e.g. "total::over" with a threshold
+message Wincase {
+  string name = 1;
+  optional int32 threshold = 2;
+  optional uint32 home = 3;
+  optional uint32 away = 4;
+}
+
+// Market is a market of a game, e.g. "total" with a threshold
+message Market {
+  string name = 1;
+  optional int32 threshold = 2;
+  optional uint32 home = 3;
+  optional uint32 away = 4;
+}
+
+// Event has the field of its event.Type, the field number is the type number + 1
+message Event {
+`)
+
+	for _, number := range s.reserved {
+		fmt.Fprintf(&b, "  reserved %d;\n", number)
+	}
+
+	b.WriteString("  oneof event {\n")
+	eventTypes := make([]event.Type, 0, len(s.events))
+	for t := range s.events {
+		eventTypes = append(eventTypes, t)
+	}
+	sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i] < eventTypes[j] })
+
+	for _, t := range eventTypes {
+		fmt.Fprintf(&b, "    %s %s = %d;\n", s.events[t].name, t, fieldNumber(t))
+	}
+	b.WriteString("  }\n}\n")
+
+	for _, t := range eventTypes {
+		s.events[t].render(&b)
+	}
+
+	nested := make([]*message, 0, len(s.nested))
+	for _, m := range s.nested {
+		nested = append(nested, m)
+	}
+	sort.Slice(nested, func(i, j int) bool { return nested[i].name < nested[j].name })
+
+	for _, m := range nested {
+		m.render(&b)
+	}
+
+	return b.String()
+}
+
+func (m *message) render(b *strings.Builder) {
+	fmt.Fprintf(b, "\nmessage %s {\n", m.name)
+	for _, number := range m.reserved {
+		fmt.Fprintf(b, "  reserved %d;\n", number)
+	}
+	for _, f := range m.fields {
+		label := ""
+		if f.repeated {
+			label = "repeated "
+		}
+		fmt.Fprintf(b, "  %s%s %s = %d;\n", label, f.kind.protoType(f.message), f.name, f.number)
+	}
+	b.WriteString("}\n")
+}
@@ -14,7 +14,9 @@ import (
 )
 
 func main() {
-	provider := provider.NewProvider("https://testnet.scorum.work", provider.SyncInterval(time.Second))
+	provider := provider.NewProvider("https://testnet.scorum.work",
+		provider.SyncInterval(time.Second),
+		provider.ChainID(provider.TestNetChainID))
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -0,0 +1,102 @@
+package projection
+
+import "github.com/scorum/event-provider-go/event"
+
+// AccountAuthorities are the last seen authorities and memo key of an account, nil or empty when not seen yet
+type AccountAuthorities struct {
+	Owner   *event.Authority
+	Active  *event.Authority
+	Posting *event.Authority
+	MemoKey string
+}
+
+// Authorities keeps the authorities and memo key of every account set by an account update or an account recovery.
+// An account update operation only carries the new authorities, Authorities adds the previous ones to its event.
+//
+// Authorities set before the first applied block, or by the creation of the account, are unknown,
+// the previous authorities of their first update are nil.
+type Authorities struct {
+	history
+	accounts map[string]AccountAuthorities
+}
+
+func NewAuthorities() *Authorities {
+	return &Authorities{
+		accounts: make(map[string]AccountAuthorities),
+	}
+}
+
+// Apply updates the authorities with the account update and recovery events of the block and returns the block
+// with the AccountUpdateEvent events replaced by copies with the previous authorities and memo key.
+// A block at or below the last applied block is a fork, the blocks from it on are rolled back first.
+func (a *Authorities) Apply(block event.Block) (event.Block, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.journal.begin(block.BlockNum); err != nil {
+		return event.Block{}, err
+	}
+
+	events := make([]event.Event, 0, len(block.Events))
+	for _, e := range block.Events {
+		switch e := e.(type) {
+		case *event.AccountUpdateEvent:
+			previous := a.accounts[e.Account]
+
+			update := *e
+			update.PreviousOwner = previous.Owner
+			update.PreviousActive = previous.Active
+			update.PreviousPosting = previous.Posting
+			update.PreviousMemoKey = previous.MemoKey
+			events = append(events, &update)
+
+			current := previous
+			if e.Owner != nil {
+				current.Owner = e.Owner
+			}
+			if e.Active != nil {
+				current.Active = e.Active
+			}
+			if e.Posting != nil {
+				current.Posting = e.Posting
+			}
+			if e.MemoKey != "" {
+				current.MemoKey = e.MemoKey
+			}
+			a.set(e.Account, current)
+		case *event.RecoverAccountEvent:
+			current := a.accounts[e.AccountToRecover]
+			owner := e.NewOwnerAuthority
+			current.Owner = &owner
+			a.set(e.AccountToRecover, current)
+			events = append(events, e)
+		default:
+			events = append(events, e)
+		}
+	}
+
+	block.Events = events
+	return block, nil
+}
+
+func (a *Authorities) set(account string, authorities AccountAuthorities) {
+	previous, exists := a.accounts[account]
+	a.accounts[account] = authorities
+
+	a.journal.record(func() {
+		if exists {
+			a.accounts[account] = previous
+		} else {
+			delete(a.accounts, account)
+		}
+	})
+}
+
+// Account returns the last seen authorities of the account, false if none were seen
+func (a *Authorities) Account(account string) (AccountAuthorities, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	authorities, ok := a.accounts[account]
+	return authorities, ok
+}
@@ -0,0 +1,63 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/scorum/event-provider-go/event"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	key1 = "SCR5jPZF7PMgTpLqkdfpMu8kXea8Gio6E646aYpTgcjr9qMLrAgnL"
+	key2 = "SCR6oVhXuUhLkFs6Eovt7ZMxfhnmzgcXhDxrGWYCdZGxSrv3HvUPk"
+)
+
+func keyAuthority(key string) *event.Authority {
+	return &event.Authority{WeightThreshold: 1, KeyAuths: []event.KeyAuth{{Key: key, Weight: 1}}}
+}
+
+func TestAuthorities(t *testing.T) {
+	authorities := NewAuthorities()
+
+	first := &event.AccountUpdateEvent{Account: "alice", Owner: keyAuthority(key1), Active: keyAuthority(key1), MemoKey: key1}
+	b, err := authorities.Apply(event.Block{BlockNum: 1, Events: []event.Event{first}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{first}, b.Events)
+	require.False(t, b.Events[0].(*event.AccountUpdateEvent).KeyRotated())
+
+	// the active key is rotated, the owner authority is not changed
+	b, err = authorities.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		&event.VoteEvent{Voter: "alice", Author: "bob", PermLink: "post", Weight: 100},
+		&event.AccountUpdateEvent{Account: "alice", Active: keyAuthority(key2), MemoKey: key1},
+	}})
+	require.NoError(t, err)
+	require.Len(t, b.Events, 2)
+	update := b.Events[1].(*event.AccountUpdateEvent)
+	require.Equal(t, &event.AccountUpdateEvent{
+		Account:         "alice",
+		Active:          keyAuthority(key2),
+		MemoKey:         key1,
+		PreviousOwner:   keyAuthority(key1),
+		PreviousActive:  keyAuthority(key1),
+		PreviousMemoKey: key1,
+	}, update)
+	require.True(t, update.KeyRotated())
+
+	// the owner authority is recovered
+	_, err = authorities.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		&event.RecoverAccountEvent{AccountToRecover: "alice", NewOwnerAuthority: *keyAuthority(key2), RecentOwnerAuthority: *keyAuthority(key1)},
+	}})
+	require.NoError(t, err)
+	account, ok := authorities.Account("alice")
+	require.True(t, ok)
+	require.Equal(t, AccountAuthorities{Owner: keyAuthority(key2), Active: keyAuthority(key2), MemoKey: key1}, account)
+
+	require.NoError(t, authorities.Rollback(1))
+	account, _ = authorities.Account("alice")
+	require.Equal(t, AccountAuthorities{Owner: keyAuthority(key1), Active: keyAuthority(key1), MemoKey: key1}, account)
+
+	authorities.Irreversible(1)
+	require.ErrorIs(t, authorities.Rollback(0), ErrIrreversible)
+	_, ok = authorities.Account("bob")
+	require.False(t, ok)
+}
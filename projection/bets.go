@@ -0,0 +1,344 @@
+package projection
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+)
+
+// BetStatus is the state of a bet in its lifecycle
+type BetStatus string
+
+const (
+	// BetPending is a bet with no matched stake
+	BetPending BetStatus = "pending"
+	// BetPartiallyMatched is a bet with both matched and pending stake
+	BetPartiallyMatched BetStatus = "partially_matched"
+	// BetMatched is a bet with matched stake only, the pending stake of a partially matched bet can be cancelled
+	BetMatched BetStatus = "matched"
+	// BetWon and BetDraw are matched bets resolved with an income
+	BetWon  BetStatus = "won"
+	BetDraw BetStatus = "draw"
+	// BetLost is a matched bet of a resolved game without an income
+	BetLost BetStatus = "lost"
+	// BetCancelledPending is a bet cancelled before any stake was matched
+	BetCancelledPending BetStatus = "cancelled_pending"
+	// BetCancelledMatched is a matched bet cancelled with its game
+	BetCancelledMatched BetStatus = "cancelled_matched"
+)
+
+// ErrUnknownResolveKind is returned by Bets.Apply for a bet resolved with a kind other than win or draw
+var ErrUnknownResolveKind = errors.New("unknown bet resolve kind")
+
+// Match is a part of a bet matched with a bet of another better
+type Match struct {
+	BlockNum     uint32
+	MatchedBetID int64
+	// BetUUID and Better are the matched bet of the counterparty
+	BetUUID uuid.UUID
+	Better  string
+	// Stake is the matched stake of the bet, CounterStake the matched stake of the counterparty
+	Stake        types.Asset
+	CounterStake types.Asset
+}
+
+// Bet is the state of a bet built from its events. The slices of a Bet are shared and must not be modified.
+type Bet struct {
+	UUID     uuid.UUID
+	Better   string
+	GameUUID uuid.UUID
+	Wincase  types.Wincase
+	Odds     types.Odds
+	Live     bool
+	Status   BetStatus
+	// Stake is the posted stake, Pending the part of it not matched yet
+	Stake   types.Asset
+	Pending types.Asset
+	Matches []Match
+	// Refunded is the stake returned by cancellations, Income the payout of the resolved bet
+	Refunded types.Asset
+	Income   types.Asset
+	// CreatedAt and UpdatedAt are the blocks the bet was posted and last changed at
+	CreatedAt uint32
+	UpdatedAt uint32
+}
+
+// MatchedStake returns the matched stake of the bet
+func (b Bet) MatchedStake() types.Asset {
+	var matched types.Asset
+	for _, m := range b.Matches {
+		matched = addAsset(matched, m.Stake)
+	}
+	return matched
+}
+
+// settled returns whether the bet is resolved or cancelled
+func (b Bet) settled() bool {
+	switch b.Status {
+	case BetWon, BetDraw, BetLost, BetCancelledPending, BetCancelledMatched:
+		return true
+	}
+	return false
+}
+
+// matchStatus returns the status of an unsettled bet by its stakes
+func (b Bet) matchStatus() BetStatus {
+	pending := b.Pending.Decimal().IsPositive()
+	switch {
+	case len(b.Matches) == 0 && pending:
+		return BetPending
+	case len(b.Matches) == 0:
+		return BetCancelledPending
+	case pending:
+		return BetPartiallyMatched
+	default:
+		return BetMatched
+	}
+}
+
+// Bets keeps the state of every bet posted after the first applied block, the events of other bets are ignored.
+type Bets struct {
+	history
+	bets map[uuid.UUID]*Bet
+}
+
+func NewBets() *Bets {
+	return &Bets{
+		bets: make(map[uuid.UUID]*Bet),
+	}
+}
+
+// Apply updates the bets with the betting events of the block. Matched bets without an income are lost once
+// their game is resolved. A bet resolved with an unknown kind returns ErrUnknownResolveKind and leaves the bets
+// as they were before the block. A block at or below the last applied block is a fork, the blocks from it on are rolled back first.
+func (bs *Bets) Apply(block event.Block) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if err := bs.journal.begin(block.BlockNum); err != nil {
+		return err
+	}
+
+	var resolvedGames []uuid.UUID
+	for _, e := range block.Events {
+		switch e := e.(type) {
+		case event.PostBetEvent:
+			if _, exists := bs.bets[e.UUID]; exists {
+				continue
+			}
+			bs.set(e.UUID, &Bet{
+				UUID:      e.UUID,
+				Better:    e.Better,
+				GameUUID:  e.GameUUID,
+				Wincase:   e.Wincase,
+				Odds:      e.Odds,
+				Live:      e.Live,
+				Status:    BetPending,
+				Stake:     e.Stake,
+				Pending:   e.Stake,
+				CreatedAt: block.BlockNum,
+				UpdatedAt: block.BlockNum,
+			})
+		case event.BetsMatchedEvent:
+			bs.update(block.BlockNum, e.Bet1UUID, func(bet *Bet) {
+				bs.match(bet, Match{block.BlockNum, e.MatchedBetID, e.Bet2UUID, e.Better2, e.MatchedStake1, e.MatchedStake2})
+			})
+			bs.update(block.BlockNum, e.Bet2UUID, func(bet *Bet) {
+				bs.match(bet, Match{block.BlockNum, e.MatchedBetID, e.Bet1UUID, e.Better1, e.MatchedStake2, e.MatchedStake1})
+			})
+		case event.CancelPendingBetEvent:
+			for _, id := range e.BetIDs {
+				bs.update(block.BlockNum, id, func(bet *Bet) {
+					bs.cancelPending(bet, bet.Pending)
+				})
+			}
+		case event.BetCancelledEvent:
+			bs.update(block.BlockNum, e.BetUUID, func(bet *Bet) {
+				if e.Kind == types.PendingBetKind {
+					// the pending stake of a bet cancelled by cancel_pending_bets is refunded already
+					if bet.Pending.Decimal().IsPositive() {
+						bs.cancelPending(bet, e.Stake)
+					}
+					return
+				}
+
+				bet.Refunded = addAsset(bet.Refunded, e.Stake)
+				bet.Status = BetCancelledMatched
+			})
+		case event.BetRestoredEvent:
+			bs.update(block.BlockNum, e.BetUUID, func(bet *Bet) {
+				bet.Pending = addAsset(bet.Pending, e.Stake)
+				bet.Refunded = subAsset(bet.Refunded, e.Stake)
+				bet.Status = bet.matchStatus()
+			})
+		case event.BetUpdatedEvent:
+			if e.Kind != types.PendingBetKind {
+				continue
+			}
+			bs.update(block.BlockNum, e.BetUUID, func(bet *Bet) {
+				bet.Pending = e.NewStake
+				if !bet.settled() {
+					bet.Status = bet.matchStatus()
+				}
+			})
+		case event.BetResolvedEvent:
+			var status BetStatus
+			switch e.Kind {
+			case types.WinBetResolveKind:
+				status = BetWon
+			case types.DrawBetResolveKind:
+				status = BetDraw
+			default:
+				// the changes of the block are reverted, the changes of the rollback before it are kept
+				_ = bs.journal.revert(block.BlockNum)
+				return fmt.Errorf("block %d, bet %s: %w: %q", block.BlockNum, e.BetUUID, ErrUnknownResolveKind, e.Kind)
+			}
+
+			bs.update(block.BlockNum, e.BetUUID, func(bet *Bet) {
+				bet.Income = addAsset(bet.Income, e.Income)
+				bet.Status = status
+			})
+		case event.GameStatusChangedEvent:
+			if e.NewStatus == types.GameStatusResolved {
+				resolvedGames = append(resolvedGames, e.GameUUID)
+			}
+		}
+	}
+
+	// the incomes of a game are resolved in the block its status changes, the bets without an income are lost
+	for _, game := range resolvedGames {
+		for id, bet := range bs.bets {
+			if bet.GameUUID == game && !bet.settled() && len(bet.Matches) != 0 {
+				bs.update(block.BlockNum, id, func(bet *Bet) {
+					bet.Status = BetLost
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bs *Bets) match(bet *Bet, m Match) {
+	bet.Matches = append(bet.Matches[:len(bet.Matches):len(bet.Matches)], m)
+	bet.Pending = subAsset(bet.Pending, m.Stake)
+	if bet.Pending.Decimal().IsNegative() {
+		bet.Pending = types.Asset{}
+	}
+	if !bet.settled() {
+		bet.Status = bet.matchStatus()
+	}
+}
+
+func (bs *Bets) cancelPending(bet *Bet, refund types.Asset) {
+	bet.Refunded = addAsset(bet.Refunded, refund)
+	bet.Pending = types.Asset{}
+	if !bet.settled() {
+		bet.Status = bet.matchStatus()
+	}
+}
+
+// update changes a copy of a known bet
+func (bs *Bets) update(blockNum uint32, id uuid.UUID, fn func(bet *Bet)) {
+	previous, exists := bs.bets[id]
+	if !exists {
+		return
+	}
+
+	bet := *previous
+	fn(&bet)
+	bet.UpdatedAt = blockNum
+
+	bs.set(id, &bet)
+}
+
+func (bs *Bets) set(id uuid.UUID, bet *Bet) {
+	previous, exists := bs.bets[id]
+	bs.bets[id] = bet
+
+	bs.journal.record(func() {
+		if exists {
+			bs.bets[id] = previous
+		} else {
+			delete(bs.bets, id)
+		}
+	})
+}
+
+// Bet returns the bet, false if it is unknown
+func (bs *Bets) Bet(id uuid.UUID) (Bet, bool) {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	bet, ok := bs.bets[id]
+	if !ok {
+		return Bet{}, false
+	}
+	return *bet, true
+}
+
+// Bets returns the bets matching the filter ordered by posting, a nil filter matches every bet
+func (bs *Bets) Bets(filter func(Bet) bool) []Bet {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	var bets []Bet
+	for _, bet := range bs.bets {
+		if filter == nil || filter(*bet) {
+			bets = append(bets, *bet)
+		}
+	}
+
+	sort.Slice(bets, func(i, j int) bool {
+		return bets[i].CreatedAt < bets[j].CreatedAt ||
+			bets[i].CreatedAt == bets[j].CreatedAt && bets[i].UUID.String() < bets[j].UUID.String()
+	})
+	return bets
+}
+
+// ByBetter returns the bets of the better ordered by posting
+func (bs *Bets) ByBetter(better string) []Bet {
+	return bs.Bets(func(bet Bet) bool {
+		return bet.Better == better
+	})
+}
+
+// ByGame returns the bets on the game ordered by posting
+func (bs *Bets) ByGame(game uuid.UUID) []Bet {
+	return bs.Bets(func(bet Bet) bool {
+		return bet.GameUUID == game
+	})
+}
+
+// ByWincase returns the bets on the wincase of the game ordered by posting, wincases are equal with the same name and meta
+func (bs *Bets) ByWincase(game uuid.UUID, wincase types.Wincase) []Bet {
+	key := wincaseKey(wincase)
+	return bs.Bets(func(bet Bet) bool {
+		return bet.GameUUID == game && wincaseKey(bet.Wincase) == key
+	})
+}
+
+// wincaseKey identifies a wincase by its name and meta, e.g. total::over with its threshold
+func wincaseKey(w types.Wincase) string {
+	if w.WincaseInterface == nil {
+		return ""
+	}
+
+	meta, err := w.GetMeta()
+	if err != nil {
+		return w.GetName()
+	}
+	return w.GetName() + string(meta)
+}
+
+func addAsset(a, b types.Asset) types.Asset {
+	return *types.AssetFromDecimal(a.Decimal().Add(b.Decimal()))
+}
+
+func subAsset(a, b types.Asset) types.Asset {
+	return *types.AssetFromDecimal(a.Decimal().Sub(b.Decimal()))
+}
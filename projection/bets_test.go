@@ -0,0 +1,201 @@
+package projection
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bobBet   = uuid.MustParse("a3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e")
+	carolBet = uuid.MustParse("b3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e")
+	daveBet  = uuid.MustParse("c3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e")
+)
+
+func mustAsset(t *testing.T, value string) types.Asset {
+	asset, err := types.AssetFromString(value)
+	require.NoError(t, err)
+	return *asset
+}
+
+func mustWincase(t *testing.T, data string) types.Wincase {
+	var wincase types.Wincase
+	require.NoError(t, json.Unmarshal([]byte(data), &wincase))
+	return wincase
+}
+
+func postBet(t *testing.T, id uuid.UUID, better, wincase, stake string) event.Event {
+	return event.PostBetEvent{PostBetOperation: types.PostBetOperation{
+		UUID:     id,
+		Better:   better,
+		GameUUID: gameUUID,
+		Wincase:  mustWincase(t, wincase),
+		Odds:     types.Odds{Numerator: 2, Denominator: 1},
+		Stake:    mustAsset(t, stake),
+	}}
+}
+
+func betsMatched(t *testing.T, bet1, bet2 uuid.UUID, better1, better2, stake1, stake2 string, id int64) event.Event {
+	return event.BetsMatchedEvent{BetsMatchedVirtualOperation: types.BetsMatchedVirtualOperation{
+		Bet1UUID: bet1, Bet2UUID: bet2, Better1: better1, Better2: better2,
+		MatchedStake1: mustAsset(t, stake1), MatchedStake2: mustAsset(t, stake2), MatchedBetID: id,
+	}}
+}
+
+func requireBet(t *testing.T, bets *Bets, id uuid.UUID, status BetStatus, pending, matched, refunded, income string) Bet {
+	bet, ok := bets.Bet(id)
+	require.True(t, ok)
+	require.Equal(t, status, bet.Status, id)
+	require.Equal(t, pending, bet.Pending.String(), "pending")
+	require.Equal(t, matched, bet.MatchedStake().String(), "matched")
+	require.Equal(t, refunded, bet.Refunded.String(), "refunded")
+	require.Equal(t, income, bet.Income.String(), "income")
+	return bet
+}
+
+func TestBets(t *testing.T) {
+	bets := NewBets()
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "10.000000000 SCR"),
+		postBet(t, carolBet, "carol", `["result_home::no", {}]`, "4.000000000 SCR"),
+		postBet(t, daveBet, "dave", `["result_home::no", {}]`, "2.000000000 SCR"),
+	}}))
+	requireBet(t, bets, bobBet, BetPending, "10.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		betsMatched(t, bobBet, carolBet, "bob", "carol", "4.000000000 SCR", "4.000000000 SCR", 1),
+	}}))
+	bob := requireBet(t, bets, bobBet, BetPartiallyMatched, "6.000000000 SCR", "4.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+	require.Equal(t, []Match{{BlockNum: 2, MatchedBetID: 1, BetUUID: carolBet, Better: "carol",
+		Stake: mustAsset(t, "4.000000000 SCR"), CounterStake: mustAsset(t, "4.000000000 SCR")}}, bob.Matches)
+	requireBet(t, bets, carolBet, BetMatched, "0.000000000 SCR", "4.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+
+	// the pending stakes are cancelled, dave's bet was never matched
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		event.CancelPendingBetEvent{CancelPendingBetsOperation: types.CancelPendingBetsOperation{BetIDs: []uuid.UUID{daveBet}, Better: "dave"}},
+		event.BetCancelledEvent{BetCancelledOperation: types.BetCancelledOperation{GameUUID: gameUUID, Better: "dave", BetUUID: daveBet,
+			Stake: mustAsset(t, "2.000000000 SCR"), Kind: types.PendingBetKind}},
+		event.BetCancelledEvent{BetCancelledOperation: types.BetCancelledOperation{GameUUID: gameUUID, Better: "bob", BetUUID: bobBet,
+			Stake: mustAsset(t, "6.000000000 SCR"), Kind: types.PendingBetKind}},
+	}}))
+	requireBet(t, bets, daveBet, BetCancelledPending, "0.000000000 SCR", "0.000000000 SCR", "2.000000000 SCR", "0.000000000 SCR")
+	requireBet(t, bets, bobBet, BetMatched, "0.000000000 SCR", "4.000000000 SCR", "6.000000000 SCR", "0.000000000 SCR")
+
+	// carol wins, bob's matched bet is lost once the game is resolved
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 4, Events: []event.Event{
+		event.BetResolvedEvent{BetResolvedOperation: types.BetResolvedOperation{GameUUID: gameUUID, Better: "carol", BetUUID: carolBet,
+			Income: mustAsset(t, "8.000000000 SCR"), Kind: types.WinBetResolveKind}},
+		statusChanged(types.GameStatusFinished, types.GameStatusResolved),
+	}}))
+	requireBet(t, bets, carolBet, BetWon, "0.000000000 SCR", "4.000000000 SCR", "0.000000000 SCR", "8.000000000 SCR")
+	requireBet(t, bets, bobBet, BetLost, "0.000000000 SCR", "4.000000000 SCR", "6.000000000 SCR", "0.000000000 SCR")
+	requireBet(t, bets, daveBet, BetCancelledPending, "0.000000000 SCR", "0.000000000 SCR", "2.000000000 SCR", "0.000000000 SCR")
+
+	require.Len(t, bets.ByGame(gameUUID), 3)
+	require.Len(t, bets.ByBetter("bob"), 1)
+	require.Empty(t, bets.ByBetter("alice"))
+
+	byWincase := bets.ByWincase(gameUUID, mustWincase(t, `["result_home::no", {}]`))
+	require.Len(t, byWincase, 2)
+	require.Equal(t, carolBet, byWincase[0].UUID)
+	require.Equal(t, daveBet, byWincase[1].UUID)
+	require.Empty(t, bets.ByWincase(uuid.New(), mustWincase(t, `["result_home::no", {}]`)))
+}
+
+func TestBets_Cancellations(t *testing.T) {
+	bets := NewBets()
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		postBet(t, bobBet, "bob", `["total::over", {"threshold": 2500}]`, "1.000000000 SCR"),
+		postBet(t, carolBet, "carol", `["total::under", {"threshold": 2500}]`, "1.000000000 SCR"),
+		postBet(t, daveBet, "dave", `["total::over", {"threshold": 1500}]`, "1.000000000 SCR"),
+		betsMatched(t, bobBet, carolBet, "bob", "carol", "1.000000000 SCR", "1.000000000 SCR", 1),
+	}}))
+
+	// the game is cancelled, matched stakes are returned
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		event.BetCancelledEvent{BetCancelledOperation: types.BetCancelledOperation{GameUUID: gameUUID, Better: "bob", BetUUID: bobBet,
+			Stake: mustAsset(t, "1.000000000 SCR"), Kind: types.MatchedBetKind}},
+		event.BetCancelledEvent{BetCancelledOperation: types.BetCancelledOperation{GameUUID: gameUUID, Better: "dave", BetUUID: daveBet,
+			Stake: mustAsset(t, "1.000000000 SCR"), Kind: types.PendingBetKind}},
+	}}))
+	requireBet(t, bets, bobBet, BetCancelledMatched, "0.000000000 SCR", "1.000000000 SCR", "1.000000000 SCR", "0.000000000 SCR")
+	requireBet(t, bets, daveBet, BetCancelledPending, "0.000000000 SCR", "0.000000000 SCR", "1.000000000 SCR", "0.000000000 SCR")
+
+	// dave's bet is restored and its stake is updated
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		event.BetRestoredEvent{GameUUID: gameUUID, Better: "dave", BetUUID: daveBet, Stake: mustAsset(t, "1.000000000 SCR")},
+		event.BetUpdatedEvent{GameUUID: gameUUID, Better: "dave", BetUUID: daveBet, Kind: types.PendingBetKind,
+			OldStake: mustAsset(t, "1.000000000 SCR"), NewStake: mustAsset(t, "0.500000000 SCR")},
+	}}))
+	requireBet(t, bets, daveBet, BetPending, "0.500000000 SCR", "0.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+
+	// the thresholds tell the wincases apart
+	require.Len(t, bets.ByWincase(gameUUID, mustWincase(t, `["total::over", {"threshold": 2500}]`)), 1)
+}
+
+func TestBets_Rollback(t *testing.T) {
+	bets := NewBets()
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "1.000000000 SCR"),
+		postBet(t, carolBet, "carol", `["result_home::no", {}]`, "1.000000000 SCR"),
+	}}))
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		betsMatched(t, bobBet, carolBet, "bob", "carol", "1.000000000 SCR", "1.000000000 SCR", 1),
+	}}))
+
+	// block 2 is replaced after a fork, bob cancels his bet instead
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		event.CancelPendingBetEvent{CancelPendingBetsOperation: types.CancelPendingBetsOperation{BetIDs: []uuid.UUID{bobBet}, Better: "bob"}},
+	}}))
+	requireBet(t, bets, bobBet, BetCancelledPending, "0.000000000 SCR", "0.000000000 SCR", "1.000000000 SCR", "0.000000000 SCR")
+	requireBet(t, bets, carolBet, BetPending, "1.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+
+	require.NoError(t, bets.Rollback(0))
+	_, ok := bets.Bet(bobBet)
+	require.False(t, ok)
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "1.000000000 SCR"),
+	}}))
+	bets.Irreversible(1)
+	require.ErrorIs(t, bets.Rollback(0), ErrIrreversible)
+}
+
+func TestBets_UnknownResolveKind(t *testing.T) {
+	bets := NewBets()
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "1.000000000 SCR"),
+		postBet(t, carolBet, "carol", `["result_home::no", {}]`, "1.000000000 SCR"),
+		betsMatched(t, bobBet, carolBet, "bob", "carol", "1.000000000 SCR", "1.000000000 SCR", 1),
+	}}))
+
+	for _, kind := range []types.BetResolveKind{"refund", ""} {
+		err := bets.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+			postBet(t, daveBet, "dave", `["result_home::yes", {}]`, "1.000000000 SCR"),
+			event.BetResolvedEvent{BetResolvedOperation: types.BetResolvedOperation{
+				GameUUID: gameUUID, Better: "bob", BetUUID: bobBet, Income: mustAsset(t, "2.000000000 SCR"), Kind: kind,
+			}},
+		}})
+		require.ErrorIs(t, err, ErrUnknownResolveKind)
+
+		// the block is not applied
+		requireBet(t, bets, bobBet, BetMatched, "0.000000000 SCR", "1.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+		_, ok := bets.Bet(daveBet)
+		require.False(t, ok)
+	}
+
+	require.NoError(t, bets.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		event.BetResolvedEvent{BetResolvedOperation: types.BetResolvedOperation{
+			GameUUID: gameUUID, Better: "bob", BetUUID: bobBet, Income: mustAsset(t, "1.000000000 SCR"), Kind: types.DrawBetResolveKind,
+		}},
+	}}))
+	requireBet(t, bets, bobBet, BetDraw, "0.000000000 SCR", "1.000000000 SCR", "0.000000000 SCR", "1.000000000 SCR")
+}
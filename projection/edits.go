@@ -0,0 +1,200 @@
+package projection
+
+import "github.com/scorum/event-provider-go/event"
+
+// ContentKey identifies a post or a comment
+type ContentKey struct {
+	Author   string
+	PermLink string
+}
+
+// Content is the last title, body and metadata of a post or a comment
+type Content struct {
+	ParentAuthor   string
+	ParentPermLink string
+	Title          string
+	Body           string
+	JsonMetadata   string
+}
+
+// Edits keeps the content of every post and comment. A comment operation of a known post or comment is an edit,
+// Edits tells it from a new post or comment and rebuilds the full body of an edit submitted as a diff patch.
+//
+// Content created before the first applied block is unknown, its edits are reported as new posts and comments.
+type Edits struct {
+	history
+	contents map[ContentKey]Content
+}
+
+func NewEdits() *Edits {
+	return &Edits{
+		contents: make(map[ContentKey]Content),
+	}
+}
+
+// Apply updates the contents with the post, comment and delete events of the block and returns the block
+// with the events of edits replaced by PostEditedEvent and CommentEditedEvent.
+// A block at or below the last applied block is a fork, the blocks from it on are rolled back first.
+func (ed *Edits) Apply(block event.Block) (event.Block, error) {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	if err := ed.journal.begin(block.BlockNum); err != nil {
+		return event.Block{}, err
+	}
+
+	events := make([]event.Event, 0, len(block.Events))
+	for _, e := range block.Events {
+		switch e := e.(type) {
+		case *event.PostEvent:
+			key := ContentKey{e.Author, e.PermLink}
+			previous, edited := ed.contents[key]
+			content, patchErr := ed.set(key, Content{
+				ParentPermLink: e.ParentPermLink,
+				Title:          e.Title,
+				Body:           e.Body,
+				JsonMetadata:   e.JsonMetadata,
+			})
+
+			if !edited {
+				events = append(events, e)
+				continue
+			}
+
+			events = append(events, &event.PostEditedEvent{
+				Author:               e.Author,
+				PermLink:             e.PermLink,
+				ParentPermLink:       e.ParentPermLink,
+				PreviousTitle:        previous.Title,
+				Title:                content.Title,
+				PreviousBody:         previous.Body,
+				Body:                 content.Body,
+				Patch:                patchOf(e.Body),
+				PreviousJsonMetadata: previous.JsonMetadata,
+				JsonMetadata:         content.JsonMetadata,
+				PatchError:           errorText(patchErr),
+			})
+		case *event.CommentEvent:
+			key := ContentKey{e.Author, e.PermLink}
+			previous, edited := ed.contents[key]
+			content, patchErr := ed.set(key, Content{
+				ParentAuthor:   e.ParentAuthor,
+				ParentPermLink: e.ParentPermLink,
+				Title:          e.Title,
+				Body:           e.Body,
+				JsonMetadata:   e.JsonMetadata,
+			})
+
+			if !edited {
+				events = append(events, e)
+				continue
+			}
+
+			events = append(events, &event.CommentEditedEvent{
+				Author:               e.Author,
+				PermLink:             e.PermLink,
+				ParentAuthor:         e.ParentAuthor,
+				ParentPermLink:       e.ParentPermLink,
+				PreviousTitle:        previous.Title,
+				Title:                content.Title,
+				PreviousBody:         previous.Body,
+				Body:                 content.Body,
+				Patch:                patchOf(e.Body),
+				PreviousJsonMetadata: previous.JsonMetadata,
+				JsonMetadata:         content.JsonMetadata,
+				PatchError:           errorText(patchErr),
+			})
+		case *event.DeleteCommentEvent:
+			ed.delete(ContentKey{e.Author, e.PermLink})
+			events = append(events, e)
+		default:
+			events = append(events, e)
+		}
+	}
+
+	block.Events = events
+	return block, nil
+}
+
+// set stores the content, the body of an edit submitted as a patch is applied to the previous body
+// and an empty title, body or metadata of an edit keeps the previous one, like the node does.
+// Like the node, a patch that can't be applied is stored as the body, the error is returned with the content.
+func (ed *Edits) set(key ContentKey, content Content) (Content, error) {
+	previous, exists := ed.contents[key]
+
+	var patchErr error
+	if exists {
+		if content.Title == "" {
+			content.Title = previous.Title
+		}
+		if content.JsonMetadata == "" {
+			content.JsonMetadata = previous.JsonMetadata
+		}
+
+		switch {
+		case content.Body == "":
+			content.Body = previous.Body
+		case isPatch(content.Body):
+			var body string
+			if body, patchErr = patch(previous.Body, content.Body); patchErr == nil {
+				content.Body = body
+			}
+		}
+	}
+
+	ed.contents[key] = content
+	ed.journal.record(func() {
+		if exists {
+			ed.contents[key] = previous
+		} else {
+			delete(ed.contents, key)
+		}
+	})
+
+	return content, patchErr
+}
+
+func (ed *Edits) delete(key ContentKey) {
+	previous, exists := ed.contents[key]
+	if !exists {
+		return
+	}
+
+	delete(ed.contents, key)
+	ed.journal.record(func() {
+		ed.contents[key] = previous
+	})
+}
+
+// patch applies the text form of a patch to the body
+func patch(body, text string) (string, error) {
+	hunks, err := parsePatch(text)
+	if err != nil {
+		return "", err
+	}
+	return applyPatch(body, hunks)
+}
+
+// patchOf returns the submitted body if it was submitted as a patch
+func patchOf(submitted string) string {
+	if isPatch(submitted) {
+		return submitted
+	}
+	return ""
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Content returns the last content of the post or the comment, false if it is unknown or deleted
+func (ed *Edits) Content(key ContentKey) (Content, bool) {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
+	content, ok := ed.contents[key]
+	return content, ok
+}
@@ -0,0 +1,139 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/scorum/event-provider-go/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdits(t *testing.T) {
+	edits := NewEdits()
+	post := ContentKey{Author: "alice", PermLink: "post"}
+
+	created := &event.PostEvent{Author: "alice", PermLink: "post", ParentPermLink: "animals", Title: "Fox",
+		Body: "The quick brown fox jumps over the lazy dog.", JsonMetadata: "{}"}
+	vote := &event.VoteEvent{Voter: "bob", Author: "alice", PermLink: "post", Weight: 10000}
+
+	block, err := edits.Apply(event.Block{BlockNum: 1, Events: []event.Event{created, vote}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{created, vote}, block.Events)
+
+	// the body of the edit is a patch of the previous body
+	patch := "@@ -7,13 +7,11 @@\n ick \n-brown\n+red\n  fox\n"
+	block, err = edits.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		&event.PostEvent{Author: "alice", PermLink: "post", ParentPermLink: "animals", Title: "Red fox", Body: patch, JsonMetadata: `{"tags":["fox"]}`},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{&event.PostEditedEvent{
+		Author:               "alice",
+		PermLink:             "post",
+		ParentPermLink:       "animals",
+		PreviousTitle:        "Fox",
+		Title:                "Red fox",
+		PreviousBody:         "The quick brown fox jumps over the lazy dog.",
+		Body:                 "The quick red fox jumps over the lazy dog.",
+		Patch:                patch,
+		PreviousJsonMetadata: "{}",
+		JsonMetadata:         `{"tags":["fox"]}`,
+	}}, block.Events)
+
+	// a full body replaces the previous one
+	block, err = edits.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		&event.CommentEvent{Author: "bob", PermLink: "re-post", ParentAuthor: "alice", ParentPermLink: "post", Body: "Nice"},
+		&event.CommentEvent{Author: "bob", PermLink: "re-post", ParentAuthor: "alice", ParentPermLink: "post", Body: "Nice fox"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{
+		&event.CommentEvent{Author: "bob", PermLink: "re-post", ParentAuthor: "alice", ParentPermLink: "post", Body: "Nice"},
+		&event.CommentEditedEvent{Author: "bob", PermLink: "re-post", ParentAuthor: "alice", ParentPermLink: "post",
+			PreviousBody: "Nice", Body: "Nice fox"},
+	}, block.Events)
+
+	// a patch that doesn't apply is stored as the body, like the node does, and flagged on the event
+	bad := "@@ -1,5 +1,5 @@\n-hello\n+world\n"
+	block, err = edits.Apply(event.Block{BlockNum: 4, Events: []event.Event{
+		&event.CommentEvent{Author: "bob", PermLink: "re-post", ParentAuthor: "alice", ParentPermLink: "post", Body: bad},
+	}})
+	require.NoError(t, err)
+	require.Len(t, block.Events, 1)
+	edited := block.Events[0].(*event.CommentEditedEvent)
+	require.Equal(t, "Nice fox", edited.PreviousBody)
+	require.Equal(t, bad, edited.Body)
+	require.Equal(t, bad, edited.Patch)
+	require.Contains(t, edited.PatchError, "patch doesn't apply")
+
+	content, ok := edits.Content(ContentKey{Author: "bob", PermLink: "re-post"})
+	require.True(t, ok)
+	require.Equal(t, bad, content.Body)
+
+	// a deleted post is created again
+	_, err = edits.Apply(event.Block{BlockNum: 5, Events: []event.Event{&event.DeleteCommentEvent{Author: "alice", PermLink: "post"}}})
+	require.NoError(t, err)
+	_, ok = edits.Content(post)
+	require.False(t, ok)
+
+	block, err = edits.Apply(event.Block{BlockNum: 6, Events: []event.Event{created}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{created}, block.Events)
+}
+
+func TestEdits_Rollback(t *testing.T) {
+	edits := NewEdits()
+	post := ContentKey{Author: "alice", PermLink: "post"}
+
+	_, err := edits.Apply(event.Block{BlockNum: 1, Events: []event.Event{&event.PostEvent{Author: "alice", PermLink: "post", Body: "v1"}}})
+	require.NoError(t, err)
+	_, err = edits.Apply(event.Block{BlockNum: 2, Events: []event.Event{&event.PostEvent{Author: "alice", PermLink: "post", Body: "v2"}}})
+	require.NoError(t, err)
+	_, err = edits.Apply(event.Block{BlockNum: 3, Events: []event.Event{&event.DeleteCommentEvent{Author: "alice", PermLink: "post"}}})
+	require.NoError(t, err)
+
+	// block 2 is replaced after a fork, the edit is seen against the body of block 1
+	block, err := edits.Apply(event.Block{BlockNum: 2, Events: []event.Event{&event.PostEvent{Author: "alice", PermLink: "post", Body: "v2'"}}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{&event.PostEditedEvent{Author: "alice", PermLink: "post", PreviousBody: "v1", Body: "v2'"}}, block.Events)
+
+	require.NoError(t, edits.Rollback(1))
+	content, ok := edits.Content(post)
+	require.True(t, ok)
+	require.Equal(t, "v1", content.Body)
+
+	require.NoError(t, edits.Rollback(0))
+	_, ok = edits.Content(post)
+	require.False(t, ok)
+
+	edits.Irreversible(1)
+	require.ErrorIs(t, edits.Rollback(0), ErrIrreversible)
+}
+
+func TestEdits_EmptyFields(t *testing.T) {
+	edits := NewEdits()
+
+	_, err := edits.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		&event.PostEvent{Author: "alice", PermLink: "post", Title: "Fox", Body: "The fox", JsonMetadata: "{}"},
+	}})
+	require.NoError(t, err)
+
+	// an edit of the metadata only keeps the title and the body
+	block, err := edits.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		&event.PostEvent{Author: "alice", PermLink: "post", JsonMetadata: `{"tags":["fox"]}`},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{&event.PostEditedEvent{Author: "alice", PermLink: "post",
+		PreviousTitle: "Fox", Title: "Fox", PreviousBody: "The fox", Body: "The fox",
+		PreviousJsonMetadata: "{}", JsonMetadata: `{"tags":["fox"]}`}}, block.Events)
+
+	// an edit of the body only keeps the title and the metadata
+	block, err = edits.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		&event.PostEvent{Author: "alice", PermLink: "post", Body: "The red fox"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{&event.PostEditedEvent{Author: "alice", PermLink: "post",
+		PreviousTitle: "Fox", Title: "Fox", PreviousBody: "The fox", Body: "The red fox",
+		PreviousJsonMetadata: `{"tags":["fox"]}`, JsonMetadata: `{"tags":["fox"]}`}}, block.Events)
+
+	content, ok := edits.Content(ContentKey{Author: "alice", PermLink: "post"})
+	require.True(t, ok)
+	require.Equal(t, Content{Title: "Fox", Body: "The red fox", JsonMetadata: `{"tags":["fox"]}`}, content)
+}
@@ -0,0 +1,371 @@
+package projection
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+)
+
+// ErrIllegalTransition is wrapped by a TransitionError
+var ErrIllegalTransition = errors.New("illegal game status transition")
+
+// TransitionError is a status change the game can't make, either from the status it has
+// or not following created → started → finished → resolved/expired
+type TransitionError struct {
+	BlockNum uint32
+	GameUUID uuid.UUID
+	// Status is the status the game has, From and To are the statuses of the change
+	Status types.GameStatus
+	From   types.GameStatus
+	To     types.GameStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("block %d, game %s in status %s: %s -> %s: %s", e.BlockNum, e.GameUUID, e.Status, e.From, e.To, ErrIllegalTransition)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrIllegalTransition
+}
+
+// transitions are the status changes a game can make, a started game expires when no results are posted in time
+var transitions = map[types.GameStatus][]types.GameStatus{
+	types.GameStatusCreated:  {types.GameStatusStarted},
+	types.GameStatusStarted:  {types.GameStatusFinished, types.GameStatusExpired},
+	types.GameStatusFinished: {types.GameStatusResolved, types.GameStatusExpired},
+}
+
+// LegalTransition returns whether a game can change from one status to the other
+func LegalTransition(from, to types.GameStatus) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StartTime is a start time of a game set at the block
+type StartTime struct {
+	BlockNum uint32
+	Time     time.Time
+}
+
+// Game is the state of a game built from its events. The slices of a Game are shared and must not be modified.
+type Game struct {
+	UUID                uuid.UUID
+	Moderator           string
+	JsonMetadata        string
+	GameType            types.GameType
+	AutoResolveDelaySec uint32
+	Markets             []types.Market
+	// StartTimes are the start time the game is created with and its updates, the last one is the current start time
+	StartTimes []StartTime
+	Status     types.GameStatus
+	// Results are the wincases of the last posted results, nil until results are posted
+	Results   []types.Wincase
+	Cancelled bool
+	// CreatedAt and UpdatedAt are the blocks the game was created and last changed at
+	CreatedAt uint32
+	UpdatedAt uint32
+}
+
+// StartTime returns the current start time of the game
+func (g Game) StartTime() time.Time {
+	if len(g.StartTimes) == 0 {
+		return time.Time{}
+	}
+	return g.StartTimes[len(g.StartTimes)-1].Time
+}
+
+// GameChange is a change of a game. Previous is nil for a created game, Game is nil for a game removed by a rollback.
+// Event is the event that changed the game, nil for a change reverted by a rollback.
+// Transition is set for a status change the game can't make, the status of the node is applied anyway.
+type GameChange struct {
+	BlockNum   uint32
+	Previous   *Game
+	Game       *Game
+	Event      event.Event
+	Transition *TransitionError
+}
+
+// Games keeps the state of every game created after the first applied block, the events of other games are ignored.
+type Games struct {
+	history
+	games       map[uuid.UUID]*Game
+	subscribers []func(GameChange)
+
+	// changes are the changes of the block being applied or rolled back
+	changes []GameChange
+	// reverted are the games before a rollback, keyed by the games changed by it, nil when no rollback is tracked
+	reverted map[uuid.UUID]*Game
+}
+
+func NewGames() *Games {
+	return &Games{
+		games: make(map[uuid.UUID]*Game),
+	}
+}
+
+// Subscribe calls fn with every change of a game once the block or the rollback that made it is applied.
+// fn is called without locks held, it can query the games.
+func (g *Games) Subscribe(fn func(GameChange)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.subscribers = append(g.subscribers, fn)
+}
+
+// Apply updates the games with the game events of the block. The chain is the source of truth, a status change
+// the game can't make is applied and reported by the Transition of its change, the rest of the block is applied.
+// A block at or below the last applied block is a fork, the blocks from it on are rolled back first.
+func (g *Games) Apply(block event.Block) error {
+	rolledBack, changes, err := g.apply(block)
+	g.notify(append(rolledBack, changes...))
+	return err
+}
+
+// apply applies the block without notifying the subscribers, it returns the changes of the fork rollback
+// before the block apart from the changes of the block
+func (g *Games) apply(block event.Block) (rolledBack, changes []GameChange, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reverted = make(map[uuid.UUID]*Game)
+	if err := g.journal.begin(block.BlockNum); err != nil {
+		g.reverted = nil
+		return nil, nil, err
+	}
+	rolledBack = g.revertedChanges(block.BlockNum)
+
+	g.changes = nil
+	for _, e := range block.Events {
+		g.applyEvent(block.BlockNum, e)
+	}
+
+	return rolledBack, g.changes, nil
+}
+
+// revert reverts the block applied by apply, its changes are not notified
+func (g *Games) revert(blockNum uint32) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// the block was just begun, it is above the last irreversible block
+	_ = g.journal.revert(blockNum)
+}
+
+func (g *Games) applyEvent(blockNum uint32, e event.Event) {
+	switch e := e.(type) {
+	case event.CreateGameEvent:
+		if _, exists := g.games[e.UUID]; exists {
+			return
+		}
+
+		game := &Game{
+			UUID:                e.UUID,
+			Moderator:           e.Moderator,
+			JsonMetadata:        e.JsonMetadata,
+			GameType:            e.GameType,
+			AutoResolveDelaySec: e.AutoResolveDelaySec,
+			Markets:             e.Markets,
+			Status:              types.GameStatusCreated,
+			CreatedAt:           blockNum,
+			UpdatedAt:           blockNum,
+		}
+		if e.StartTime.Time != nil {
+			game.StartTimes = []StartTime{{BlockNum: blockNum, Time: *e.StartTime.Time}}
+		}
+		g.set(blockNum, e.UUID, game, e, nil)
+	case event.UpdateGameStartTimeEvent:
+		g.update(blockNum, e.UUID, e, func(game *Game) *TransitionError {
+			if e.StartTime.Time != nil {
+				game.StartTimes = append(game.StartTimes[:len(game.StartTimes):len(game.StartTimes)],
+					StartTime{BlockNum: blockNum, Time: *e.StartTime.Time})
+			}
+			return nil
+		})
+	case event.UpdateGameMarketsEvent:
+		g.update(blockNum, e.UUID, e, func(game *Game) *TransitionError {
+			game.Markets = e.Markets
+			return nil
+		})
+	case event.PostGameResultsEvent:
+		g.update(blockNum, e.UUID, e, func(game *Game) *TransitionError {
+			game.Results = e.Wincases
+			return nil
+		})
+	case event.CancelGameEvent:
+		g.update(blockNum, e.UUID, e, func(game *Game) *TransitionError {
+			game.Cancelled = true
+			return nil
+		})
+	case event.GameStatusChangedEvent:
+		g.update(blockNum, e.GameUUID, e, func(game *Game) *TransitionError {
+			var transitionErr *TransitionError
+			if game.Status != e.OldStatus || !LegalTransition(e.OldStatus, e.NewStatus) {
+				transitionErr = &TransitionError{
+					BlockNum: blockNum,
+					GameUUID: e.GameUUID,
+					Status:   game.Status,
+					From:     e.OldStatus,
+					To:       e.NewStatus,
+				}
+			}
+			game.Status = e.NewStatus
+			return transitionErr
+		})
+	}
+}
+
+// update changes a copy of a known game, fn returns the illegal status change it made
+func (g *Games) update(blockNum uint32, id uuid.UUID, e event.Event, fn func(game *Game) *TransitionError) {
+	previous, exists := g.games[id]
+	if !exists {
+		return
+	}
+
+	game := *previous
+	transitionErr := fn(&game)
+	game.UpdatedAt = blockNum
+
+	g.set(blockNum, id, &game, e, transitionErr)
+}
+
+// set stores the game and records its change with the illegal status change of the event, if any
+func (g *Games) set(blockNum uint32, id uuid.UUID, game *Game, e event.Event, transitionErr *TransitionError) {
+	previous, exists := g.games[id]
+	g.games[id] = game
+
+	g.journal.record(func() {
+		g.restore(id, previous, exists)
+	})
+
+	change := GameChange{BlockNum: blockNum, Game: copyGame(game), Event: e, Transition: transitionErr}
+	if exists {
+		change.Previous = copyGame(previous)
+	}
+	g.changes = append(g.changes, change)
+}
+
+// restore reverts a game and keeps the game it had before the rollback
+func (g *Games) restore(id uuid.UUID, previous *Game, exists bool) {
+	if _, seen := g.reverted[id]; !seen && g.reverted != nil {
+		g.reverted[id] = g.games[id]
+	}
+
+	if exists {
+		g.games[id] = previous
+	} else {
+		delete(g.games, id)
+	}
+}
+
+// revertedChanges returns the changes of the games reverted by a rollback
+func (g *Games) revertedChanges(blockNum uint32) []GameChange {
+	var changes []GameChange
+	for id, before := range g.reverted {
+		change := GameChange{BlockNum: blockNum, Previous: copyGame(before)}
+		if game, exists := g.games[id]; exists {
+			change.Game = copyGame(game)
+		}
+		changes = append(changes, change)
+	}
+	g.reverted = nil
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Previous.CreatedAt < changes[j].Previous.CreatedAt ||
+			changes[i].Previous.CreatedAt == changes[j].Previous.CreatedAt &&
+				changes[i].Previous.UUID.String() < changes[j].Previous.UUID.String()
+	})
+	return changes
+}
+
+func (g *Games) notify(changes []GameChange) {
+	g.mu.RLock()
+	subscribers := g.subscribers
+	g.mu.RUnlock()
+
+	for _, change := range changes {
+		for _, fn := range subscribers {
+			fn(change)
+		}
+	}
+}
+
+func copyGame(game *Game) *Game {
+	if game == nil {
+		return nil
+	}
+	c := *game
+	return &c
+}
+
+// Game returns the game, false if it is unknown
+func (g *Games) Game(id uuid.UUID) (Game, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	game, ok := g.games[id]
+	if !ok {
+		return Game{}, false
+	}
+	return *game, true
+}
+
+// Games returns the games matching the filter ordered by creation, a nil filter matches every game
+func (g *Games) Games(filter func(Game) bool) []Game {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var games []Game
+	for _, game := range g.games {
+		if filter == nil || filter(*game) {
+			games = append(games, *game)
+		}
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].CreatedAt < games[j].CreatedAt ||
+			games[i].CreatedAt == games[j].CreatedAt && games[i].UUID.String() < games[j].UUID.String()
+	})
+	return games
+}
+
+// ByStatus returns the games in the status that are not cancelled, ordered by creation
+func (g *Games) ByStatus(status types.GameStatus) []Game {
+	return g.Games(func(game Game) bool {
+		return game.Status == status && !game.Cancelled
+	})
+}
+
+// ByModerator returns the games of the moderator ordered by creation
+func (g *Games) ByModerator(moderator string) []Game {
+	return g.Games(func(game Game) bool {
+		return game.Moderator == moderator
+	})
+}
+
+// Rollback rolls the games back like every projection, and notifies the subscribers of the reverted games
+func (g *Games) Rollback(blockNum uint32) error {
+	changes, err := g.rollback(blockNum)
+	g.notify(changes)
+	return err
+}
+
+func (g *Games) rollback(blockNum uint32) ([]GameChange, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reverted = make(map[uuid.UUID]*Game)
+	if err := g.journal.rollback(blockNum); err != nil {
+		g.reverted = nil
+		return nil, err
+	}
+	return g.revertedChanges(blockNum), nil
+}
@@ -0,0 +1,209 @@
+package projection
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var gameUUID = uuid.MustParse("e629f9aa-6b2c-46aa-8fa8-36770e7a7a5f")
+
+func gameTime(hour int) types.Time {
+	t := time.Date(2019, 6, 1, hour, 0, 0, 0, time.UTC)
+	return types.Time{Time: &t}
+}
+
+func mustMarkets(t *testing.T, data string) []types.Market {
+	var markets []types.Market
+	require.NoError(t, json.Unmarshal([]byte(data), &markets))
+	return markets
+}
+
+func mustWincases(t *testing.T, data string) []types.Wincase {
+	var wincases []types.Wincase
+	require.NoError(t, json.Unmarshal([]byte(data), &wincases))
+	return wincases
+}
+
+func createGame(t *testing.T) event.Event {
+	return event.CreateGameEvent{CreateGameOperation: types.CreateGameOperation{
+		UUID:                gameUUID,
+		Moderator:           "alice",
+		JsonMetadata:        `{"home":"Juventus","away":"Napoli"}`,
+		GameType:            types.SoccerGameType,
+		StartTime:           gameTime(18),
+		AutoResolveDelaySec: 86400,
+		Markets:             mustMarkets(t, `[["result_home", {}], ["total", {"threshold": 2500}]]`),
+	}}
+}
+
+func statusChanged(from, to types.GameStatus) event.Event {
+	return event.GameStatusChangedEvent{GameStatusChangedVirtualOperation: types.GameStatusChangedVirtualOperation{
+		GameUUID: gameUUID, OldStatus: from, NewStatus: to,
+	}}
+}
+
+func TestGames(t *testing.T) {
+	games := NewGames()
+
+	var changes []GameChange
+	games.Subscribe(func(change GameChange) {
+		// the games can be queried from a subscriber
+		_, ok := games.Game(gameUUID)
+		require.Equal(t, change.Game != nil, ok)
+		changes = append(changes, change)
+	})
+
+	markets := mustMarkets(t, `[["result_home", {}], ["correct_score", {"home": 1, "away": 0}]]`)
+	results := mustWincases(t, `[["result_home::yes", {}], ["correct_score::yes", {"home": 1, "away": 0}]]`)
+
+	blocks := []event.Block{
+		{BlockNum: 1, Events: []event.Event{createGame(t)}},
+		{BlockNum: 2, Events: []event.Event{
+			event.UpdateGameStartTimeEvent{UpdateGameStartTimeOperation: types.UpdateGameStartTimeOperation{UUID: gameUUID, Moderator: "alice", StartTime: gameTime(19)}},
+			event.UpdateGameMarketsEvent{UUID: gameUUID, Moderator: "alice", Markets: markets},
+		}},
+		{BlockNum: 3, Events: []event.Event{statusChanged(types.GameStatusCreated, types.GameStatusStarted)}},
+		{BlockNum: 4, Events: []event.Event{
+			event.PostGameResultsEvent{PostGameResultsOperation: types.PostGameResultsOperation{UUID: gameUUID, Moderator: "alice", Wincases: results}},
+			statusChanged(types.GameStatusStarted, types.GameStatusFinished),
+		}},
+		{BlockNum: 5, Events: []event.Event{statusChanged(types.GameStatusFinished, types.GameStatusResolved)}},
+	}
+
+	for _, block := range blocks {
+		require.NoError(t, games.Apply(block))
+	}
+
+	game, ok := games.Game(gameUUID)
+	require.True(t, ok)
+	require.Equal(t, Game{
+		UUID:                gameUUID,
+		Moderator:           "alice",
+		JsonMetadata:        `{"home":"Juventus","away":"Napoli"}`,
+		GameType:            types.SoccerGameType,
+		AutoResolveDelaySec: 86400,
+		Markets:             markets,
+		StartTimes:          []StartTime{{BlockNum: 1, Time: *gameTime(18).Time}, {BlockNum: 2, Time: *gameTime(19).Time}},
+		Status:              types.GameStatusResolved,
+		Results:             results,
+		CreatedAt:           1,
+		UpdatedAt:           5,
+	}, game)
+	require.Equal(t, *gameTime(19).Time, game.StartTime())
+
+	require.Len(t, changes, 7)
+	require.Nil(t, changes[0].Previous)
+	require.Equal(t, types.GameStatusCreated, changes[0].Game.Status)
+	require.Equal(t, blocks[0].Events[0], changes[0].Event)
+	require.Equal(t, types.GameStatusFinished, changes[6].Previous.Status)
+	require.Equal(t, types.GameStatusResolved, changes[6].Game.Status)
+
+	require.Equal(t, []Game{game}, games.ByStatus(types.GameStatusResolved))
+	require.Empty(t, games.ByStatus(types.GameStatusCreated))
+	require.Equal(t, []Game{game}, games.ByModerator("alice"))
+	require.Empty(t, games.ByModerator("bob"))
+
+	// events of games created before the first applied block are ignored
+	require.NoError(t, games.Apply(event.Block{BlockNum: 6, Events: []event.Event{
+		event.CancelGameEvent{CancelGameOperation: types.CancelGameOperation{UUID: uuid.New(), Moderator: "alice"}},
+	}}))
+	require.Len(t, changes, 7)
+}
+
+func TestGames_IllegalTransition(t *testing.T) {
+	cases := []struct {
+		from, to types.GameStatus
+	}{
+		{types.GameStatusCreated, types.GameStatusFinished},
+		{types.GameStatusCreated, types.GameStatusResolved},
+		// the game is not started
+		{types.GameStatusStarted, types.GameStatusFinished},
+	}
+
+	for _, c := range cases {
+		games := NewGames()
+		require.NoError(t, games.Apply(event.Block{BlockNum: 1, Events: []event.Event{createGame(t)}}))
+
+		var changes []GameChange
+		games.Subscribe(func(change GameChange) {
+			changes = append(changes, change)
+		})
+
+		other := uuid.New()
+		require.NoError(t, games.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+			event.CancelGameEvent{CancelGameOperation: types.CancelGameOperation{UUID: gameUUID, Moderator: "alice"}},
+			statusChanged(c.from, c.to),
+			event.CreateGameEvent{CreateGameOperation: types.CreateGameOperation{UUID: other, Moderator: "bob"}},
+		}}))
+
+		// the chain is the source of truth, the status is applied with the rest of the block
+		game, _ := games.Game(gameUUID)
+		require.True(t, game.Cancelled)
+		require.Equal(t, c.to, game.Status)
+		_, ok := games.Game(other)
+		require.True(t, ok)
+
+		// the illegal transition is reported by the change of the game only
+		require.Len(t, changes, 3)
+		require.Nil(t, changes[0].Transition)
+		require.Nil(t, changes[2].Transition)
+
+		transitionErr := changes[1].Transition
+		require.NotNil(t, transitionErr)
+		require.ErrorIs(t, transitionErr, ErrIllegalTransition)
+		require.Equal(t, TransitionError{BlockNum: 2, GameUUID: gameUUID, Status: types.GameStatusCreated, From: c.from, To: c.to}, *transitionErr)
+		require.Equal(t, types.GameStatusCreated, changes[1].Previous.Status)
+		require.Equal(t, c.to, changes[1].Game.Status)
+	}
+
+	require.True(t, LegalTransition(types.GameStatusStarted, types.GameStatusExpired))
+	require.False(t, LegalTransition(types.GameStatusResolved, types.GameStatusExpired))
+}
+
+func TestGames_Rollback(t *testing.T) {
+	games := NewGames()
+
+	var changes []GameChange
+	games.Subscribe(func(change GameChange) {
+		changes = append(changes, change)
+	})
+
+	require.NoError(t, games.Apply(event.Block{BlockNum: 1, Events: []event.Event{createGame(t)}}))
+	require.NoError(t, games.Apply(event.Block{BlockNum: 2, Events: []event.Event{statusChanged(types.GameStatusCreated, types.GameStatusStarted)}}))
+
+	// block 2 is replaced after a fork by a block cancelling the game
+	changes = nil
+	require.NoError(t, games.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		event.CancelGameEvent{CancelGameOperation: types.CancelGameOperation{UUID: gameUUID, Moderator: "alice"}},
+	}}))
+
+	require.Len(t, changes, 2)
+	require.Nil(t, changes[0].Event)
+	require.Equal(t, types.GameStatusStarted, changes[0].Previous.Status)
+	require.Equal(t, types.GameStatusCreated, changes[0].Game.Status)
+	require.True(t, changes[1].Game.Cancelled)
+
+	game, _ := games.Game(gameUUID)
+	require.Equal(t, types.GameStatusCreated, game.Status)
+	require.True(t, game.Cancelled)
+	require.Empty(t, games.ByStatus(types.GameStatusCreated))
+
+	// the game is removed by the rollback of its creation
+	changes = nil
+	require.NoError(t, games.Rollback(0))
+	_, ok := games.Game(gameUUID)
+	require.False(t, ok)
+	require.Len(t, changes, 1)
+	require.Nil(t, changes[0].Game)
+
+	require.NoError(t, games.Apply(event.Block{BlockNum: 1, Events: []event.Event{createGame(t)}}))
+	games.Irreversible(1)
+	require.ErrorIs(t, games.Rollback(0), ErrIrreversible)
+	require.ErrorIs(t, games.Apply(event.Block{BlockNum: 1}), ErrIrreversible)
+}
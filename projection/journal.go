@@ -0,0 +1,124 @@
+// Package projection keeps state derived from the blocks of a provider stream.
+// Projections fed from the reversible stream are rolled back when a block is provided again after a fork.
+package projection
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ErrIrreversible is returned when a projection is asked to roll back a block that was marked irreversible
+var ErrIrreversible = errors.New("block is irreversible")
+
+// history is embedded by the projections, it guards their state and keeps the journal of their blocks
+type history struct {
+	mu      sync.RWMutex
+	journal journal
+}
+
+// Rollback reverts the blocks after the block, e.g. when the consumer restarts the reversible stream after a fork
+func (h *history) Rollback(blockNum uint32) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.journal.rollback(blockNum)
+}
+
+// Irreversible forgets the history of the blocks up to the block, they can't be rolled back anymore.
+// It is called with the blocks of the irreversible stream, or after every Apply when only that stream is applied.
+func (h *history) Irreversible(blockNum uint32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.journal.commit(blockNum)
+}
+
+// journal keeps the undo functions of the applied blocks until they are irreversible
+type journal struct {
+	blocks []journalBlock
+	// applied is set once a block is applied, lastBlock is the last applied block
+	applied   bool
+	lastBlock uint32
+	// committed is set once a block is irreversible, irreversible is the last block that can't be reverted
+	committed    bool
+	irreversible uint32
+}
+
+type journalBlock struct {
+	blockNum uint32
+	undo     []func()
+}
+
+// begin starts recording the changes of the block. A block at or below the last applied block is a fork,
+// the blocks from it on are reverted first.
+func (j *journal) begin(blockNum uint32) error {
+	if j.applied && blockNum <= j.lastBlock {
+		if err := j.revert(blockNum); err != nil {
+			return err
+		}
+	}
+
+	j.blocks = append(j.blocks, journalBlock{blockNum: blockNum})
+	j.applied = true
+	j.lastBlock = blockNum
+	return nil
+}
+
+// record keeps the function reverting a change of the current block
+func (j *journal) record(undo func()) {
+	last := &j.blocks[len(j.blocks)-1]
+	last.undo = append(last.undo, undo)
+}
+
+// revert reverts the blocks from the block on, the last one first
+func (j *journal) revert(from uint32) error {
+	if j.committed && from <= j.irreversible {
+		return fmt.Errorf("%w: can't revert block %d, the last irreversible block is %d", ErrIrreversible, from, j.irreversible)
+	}
+
+	for len(j.blocks) > 0 {
+		last := j.blocks[len(j.blocks)-1]
+		if last.blockNum < from {
+			break
+		}
+
+		for i := len(last.undo) - 1; i >= 0; i-- {
+			last.undo[i]()
+		}
+		j.blocks = j.blocks[:len(j.blocks)-1]
+	}
+
+	if len(j.blocks) > 0 {
+		j.lastBlock = j.blocks[len(j.blocks)-1].blockNum
+	} else if j.committed {
+		j.lastBlock = j.irreversible
+	} else {
+		j.applied = false
+	}
+	return nil
+}
+
+// rollback reverts the blocks after the block
+func (j *journal) rollback(blockNum uint32) error {
+	if blockNum == math.MaxUint32 {
+		return nil
+	}
+	return j.revert(blockNum + 1)
+}
+
+// commit forgets the undo functions of the blocks up to the block, they can't be reverted anymore
+func (j *journal) commit(blockNum uint32) {
+	if j.committed && blockNum <= j.irreversible {
+		return
+	}
+	j.committed = true
+	j.irreversible = blockNum
+
+	n := 0
+	for n < len(j.blocks) && j.blocks[n].blockNum <= blockNum {
+		n++
+	}
+	j.blocks = append(j.blocks[:0], j.blocks[n:]...)
+}
@@ -0,0 +1,56 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	var (
+		j     journal
+		state []uint32
+	)
+	apply := func(blockNum uint32) {
+		require.NoError(t, j.begin(blockNum))
+		state = append(state, blockNum)
+		j.record(func() { state = state[:len(state)-1] })
+	}
+
+	apply(0)
+	apply(1)
+	apply(3)
+	require.Equal(t, []uint32{0, 1, 3}, state)
+
+	// block 3 is provided again after a fork
+	apply(3)
+	require.Equal(t, []uint32{0, 1, 3}, state)
+
+	// block 1 is provided again, blocks 1 and 3 are reverted
+	apply(1)
+	require.Equal(t, []uint32{0, 1}, state)
+
+	require.NoError(t, j.rollback(0))
+	require.Equal(t, []uint32{0}, state)
+
+	apply(1)
+	apply(2)
+	j.commit(1)
+	require.ErrorIs(t, j.rollback(0), ErrIrreversible)
+	require.ErrorIs(t, j.begin(1), ErrIrreversible)
+	require.Equal(t, []uint32{0, 1, 2}, state)
+
+	require.NoError(t, j.rollback(1))
+	require.Equal(t, []uint32{0, 1}, state)
+	require.Equal(t, uint32(1), j.lastBlock)
+
+	apply(2)
+	require.Equal(t, []uint32{0, 1, 2}, state)
+
+	// a block is provided again from the start
+	var j2 journal
+	require.NoError(t, j2.begin(0))
+	j2.record(func() { state = nil })
+	require.NoError(t, j2.begin(0))
+	require.Nil(t, state)
+}
@@ -0,0 +1,145 @@
+package projection
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var errPatchNotApplied = errors.New("patch doesn't apply")
+
+// hunk is a patch of a text in the diff-match-patch format, the body of a comment edit can be submitted as such a patch.
+// Positions are counted in characters like diff-match-patch does, not in bytes.
+type hunk struct {
+	// start is the position of before in the text patched by the previous hunks
+	start  int
+	before []rune
+	after  []rune
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+),?(\d*) \+(\d+),?(\d*) @@$`)
+
+// isPatch returns whether the body looks like a diff-match-patch patch
+func isPatch(body string) bool {
+	return strings.HasPrefix(body, "@@ -")
+}
+
+// parsePatch parses the text form of diff-match-patch patches
+func parsePatch(text string) ([]hunk, error) {
+	var hunks []hunk
+
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if line[0] == '@' {
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("invalid patch header %q", line)
+			}
+
+			start, err := strconv.Atoi(m[3])
+			if err != nil {
+				return nil, err
+			}
+			// the start is 1-based unless the hunk is empty
+			if m[4] != "0" {
+				start--
+			}
+
+			hunks = append(hunks, hunk{start: start})
+			continue
+		}
+
+		if len(hunks) == 0 {
+			return nil, errors.New("patch doesn't start with a header")
+		}
+
+		value, err := url.PathUnescape(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		h := &hunks[len(hunks)-1]
+		switch line[0] {
+		case ' ':
+			h.before = append(h.before, []rune(value)...)
+			h.after = append(h.after, []rune(value)...)
+		case '-':
+			h.before = append(h.before, []rune(value)...)
+		case '+':
+			h.after = append(h.after, []rune(value)...)
+		default:
+			return nil, fmt.Errorf("invalid patch line %q", line)
+		}
+	}
+
+	if len(hunks) == 0 {
+		return nil, errors.New("empty patch")
+	}
+
+	return hunks, nil
+}
+
+// applyPatch applies the hunks to the text. The text around a change must match exactly,
+// a hunk is applied at the occurrence of its text nearest to the position it was made at.
+func applyPatch(text string, hunks []hunk) (string, error) {
+	runes := []rune(text)
+	// delta is the shift of the text found by the previous hunks from their positions
+	delta := 0
+
+	for _, h := range hunks {
+		expected := h.start + delta
+
+		at := nearestIndex(runes, h.before, expected)
+		if at < 0 {
+			return "", fmt.Errorf("%w: %q not found", errPatchNotApplied, string(h.before))
+		}
+
+		patched := make([]rune, 0, len(runes)-len(h.before)+len(h.after))
+		patched = append(patched, runes[:at]...)
+		patched = append(patched, h.after...)
+		runes = append(patched, runes[at+len(h.before):]...)
+		delta = at - h.start
+	}
+
+	return string(runes), nil
+}
+
+// nearestIndex returns the index of the occurrence of sub nearest to the position, or -1
+func nearestIndex(s, sub []rune, position int) int {
+	best := -1
+	for i := 0; i <= len(s)-len(sub); i++ {
+		if !hasPrefix(s[i:], sub) {
+			continue
+		}
+
+		if best < 0 || abs(i-position) < abs(best-position) {
+			best = i
+		}
+		if i > position {
+			break
+		}
+	}
+	return best
+}
+
+func hasPrefix(s, prefix []rune) bool {
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
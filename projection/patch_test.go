@@ -0,0 +1,84 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "two hunks",
+			text:     "The quick brown fox jumps over the lazy dog.",
+			patch:    "@@ -7,13 +7,11 @@\n ick \n-brown\n+red\n  fox\n@@ -37,8 +35,8 @@\n azy \n-dog\n+cat\n .\n",
+			expected: "The quick red fox jumps over the lazy cat.",
+		},
+		{
+			name:     "escaped characters",
+			text:     "100% done",
+			patch:    "@@ -1,9 +1,18 @@\n 100%25 done\n+%0A%5Bdetails%5D\n",
+			expected: "100% done\n[details]",
+		},
+		{
+			name:     "moved text",
+			text:     "intro. The quick brown fox",
+			patch:    "@@ -1,13 +1,11 @@\n ick \n-brown\n+red\n  fox\n",
+			expected: "intro. The quick red fox",
+		},
+		{
+			name:     "nearest occurrence",
+			text:     "a b a b a b",
+			patch:    "@@ -9,3 +9,3 @@\n  \n-a\n+c\n  \n",
+			expected: "a b a b c b",
+		},
+		{
+			name:     "positions in characters",
+			text:     "Привет! x x",
+			patch:    "@@ -11,1 +11,1 @@\n-x\n+y\n",
+			expected: "Привет! x y",
+		},
+		{
+			name:     "multibyte change",
+			text:     "Ärger über Öl",
+			patch:    "@@ -4,7 +4,7 @@\n ger \n-%C3%BC\n+%C3%9C\n ber\n",
+			expected: "Ärger Über Öl",
+		},
+		{
+			name:     "insertion into empty text",
+			text:     "",
+			patch:    "@@ -0,0 +1,5 @@\n+Hello\n",
+			expected: "Hello",
+		},
+	}
+
+	for _, c := range cases {
+		hunks, err := parsePatch(c.patch)
+		require.NoError(t, err, c.name)
+
+		patched, err := applyPatch(c.text, hunks)
+		require.NoError(t, err, c.name)
+		require.Equal(t, c.expected, patched, c.name)
+	}
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	_, err := parsePatch("@@ -1 +1 @@\n*x\n")
+	require.Error(t, err)
+
+	_, err = parsePatch("@@ -1,2 @@\n x\n")
+	require.Error(t, err)
+
+	_, err = parsePatch(" x\n")
+	require.Error(t, err)
+
+	hunks, err := parsePatch("@@ -1,5 +1,5 @@\n-hello\n+world\n")
+	require.NoError(t, err)
+	_, err = applyPatch("goodbye", hunks)
+	require.ErrorIs(t, err, errPatchNotApplied)
+}
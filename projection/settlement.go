@@ -0,0 +1,252 @@
+package projection
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+)
+
+// Outcome is the outcome of a wincase by the results of its game
+type Outcome string
+
+const (
+	// OutcomeWin is a wincase of the results, its bets are paid both matched stakes
+	OutcomeWin Outcome = "win"
+	// OutcomeLose is a wincase of a market with another wincase in the results, its bets are paid nothing
+	OutcomeLose Outcome = "lose"
+	// OutcomeDraw is a wincase of a market without a wincase in the results, e.g. a total equal to its threshold.
+	// Its bets are paid back their matched stake.
+	OutcomeDraw Outcome = "draw"
+)
+
+// WincaseOutcome is the outcome of a wincase of a market
+type WincaseOutcome struct {
+	Wincase types.Wincase
+	Outcome Outcome
+}
+
+// Resolution is the outcome of the wincases of a game by its posted results
+type Resolution struct {
+	markets  []types.Market
+	won      map[string]bool
+	resolved map[string]bool
+}
+
+// Resolve resolves the wincases of the markets by the result wincases, like the node does once the game is resolved
+func Resolve(markets []types.Market, results []types.Wincase) Resolution {
+	r := Resolution{
+		markets:  markets,
+		won:      make(map[string]bool),
+		resolved: make(map[string]bool),
+	}
+	for _, w := range results {
+		r.won[wincaseKey(w)] = true
+		r.resolved[wincaseMarketKey(w)] = true
+	}
+	return r
+}
+
+// Outcome returns the outcome of the wincase. A wincase of a market without results is a draw,
+// as the node returns the stakes of a matched bet when neither of its wincases is in the results.
+func (r Resolution) Outcome(w types.Wincase) Outcome {
+	switch {
+	case r.won[wincaseKey(w)]:
+		return OutcomeWin
+	case r.resolved[wincaseMarketKey(w)]:
+		return OutcomeLose
+	default:
+		return OutcomeDraw
+	}
+}
+
+// Wincases returns the outcome of both wincases of every market of the game, in the order of the markets
+func (r Resolution) Wincases() []WincaseOutcome {
+	var outcomes []WincaseOutcome
+	for _, m := range r.markets {
+		for _, w := range marketWincases(m) {
+			outcomes = append(outcomes, WincaseOutcome{Wincase: w, Outcome: r.Outcome(w)})
+		}
+	}
+	return outcomes
+}
+
+// ExpectedIncome returns the income of the matched stakes of the bet by the outcome of its wincase
+func (r Resolution) ExpectedIncome(bet Bet) types.Asset {
+	var income types.Asset
+	for _, m := range bet.Matches {
+		switch r.Outcome(bet.Wincase) {
+		case OutcomeWin:
+			income = addAsset(income, addAsset(m.Stake, m.CounterStake))
+		case OutcomeDraw:
+			income = addAsset(income, m.Stake)
+		}
+	}
+	return income
+}
+
+// Discrepancy is a resolved bet whose status or income by the BetResolvedEvent stream differs from the expected ones
+type Discrepancy struct {
+	BlockNum uint32
+	GameUUID uuid.UUID
+	BetUUID  uuid.UUID
+	Better   string
+	Wincase  types.Wincase
+	// Outcome and ExpectedIncome are computed from the results, Status and Income are the resolved ones of the bet
+	Outcome        Outcome
+	ExpectedIncome types.Asset
+	Status         BetStatus
+	Income         types.Asset
+}
+
+// Reconcile compares the resolved bets of the game with the outcomes of its results.
+// Bets without matched stake and bets cancelled with their game or their market are not reconciled.
+func Reconcile(blockNum uint32, game Game, bets []Bet) []Discrepancy {
+	resolution := Resolve(game.Markets, game.Results)
+
+	var discrepancies []Discrepancy
+	for _, bet := range bets {
+		if bet.GameUUID != game.UUID || len(bet.Matches) == 0 || bet.Status == BetCancelledMatched {
+			continue
+		}
+
+		outcome := resolution.Outcome(bet.Wincase)
+		expected := resolution.ExpectedIncome(bet)
+		if bet.Status == outcomeStatus(outcome) && bet.Income.Decimal().Equal(expected.Decimal()) {
+			continue
+		}
+
+		discrepancies = append(discrepancies, Discrepancy{
+			BlockNum:       blockNum,
+			GameUUID:       game.UUID,
+			BetUUID:        bet.UUID,
+			Better:         bet.Better,
+			Wincase:        bet.Wincase,
+			Outcome:        outcome,
+			ExpectedIncome: expected,
+			Status:         bet.Status,
+			Income:         bet.Income,
+		})
+	}
+	return discrepancies
+}
+
+func outcomeStatus(outcome Outcome) BetStatus {
+	switch outcome {
+	case OutcomeWin:
+		return BetWon
+	case OutcomeDraw:
+		return BetDraw
+	default:
+		return BetLost
+	}
+}
+
+// Settlement applies the blocks to the games and the bets and reconciles the bets of every game resolved by a block
+type Settlement struct {
+	games *Games
+	bets  *Bets
+}
+
+func NewSettlement(games *Games, bets *Bets) *Settlement {
+	return &Settlement{games: games, bets: bets}
+}
+
+// Apply applies the block to the games and then to the bets, and returns the discrepancies of the games resolved by it.
+// The node resolves the bets in the block the game status changes to resolved.
+// The block is applied to both or to none: when the bets can't apply it, the games revert it too.
+// The subscribers of the games are notified of its changes once the bets applied it.
+func (s *Settlement) Apply(block event.Block) ([]Discrepancy, error) {
+	rolledBack, changes, err := s.games.apply(block)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bets.Apply(block); err != nil {
+		// the changes of the block are reverted, the changes of the rollback before it are kept
+		s.games.revert(block.BlockNum)
+		s.games.notify(rolledBack)
+		return nil, err
+	}
+	s.games.notify(append(rolledBack, changes...))
+
+	var discrepancies []Discrepancy
+	for _, e := range block.Events {
+		changed, ok := e.(event.GameStatusChangedEvent)
+		if !ok || changed.NewStatus != types.GameStatusResolved {
+			continue
+		}
+
+		game, ok := s.games.Game(changed.GameUUID)
+		if !ok {
+			continue
+		}
+		discrepancies = append(discrepancies, Reconcile(block.BlockNum, game, s.bets.ByGame(game.UUID))...)
+	}
+	return discrepancies, nil
+}
+
+// Rollback rolls back the games and then the bets
+func (s *Settlement) Rollback(blockNum uint32) error {
+	if err := s.games.Rollback(blockNum); err != nil {
+		return err
+	}
+	return s.bets.Rollback(blockNum)
+}
+
+// Irreversible marks the block irreversible for the games and the bets
+func (s *Settlement) Irreversible(blockNum uint32) {
+	s.games.Irreversible(blockNum)
+	s.bets.Irreversible(blockNum)
+}
+
+// wincaseMarketKey identifies the market of a wincase by the market name and the wincase meta, e.g. total with its threshold
+func wincaseMarketKey(w types.Wincase) string {
+	if w.WincaseInterface == nil {
+		return ""
+	}
+
+	name := w.GetName()
+	if i := strings.Index(name, "::"); i >= 0 {
+		name = name[:i]
+	}
+
+	meta, err := w.GetMeta()
+	if err != nil {
+		return name
+	}
+	return name + string(meta)
+}
+
+// marketWincases returns both wincases of the market, e.g. total::over and total::under with the threshold of the market.
+// The wincases of a market are numbered after it, none are returned for a market without wincases.
+func marketWincases(m types.Market) []types.Wincase {
+	if m.MarketInterface == nil {
+		return nil
+	}
+
+	meta, err := m.GetMeta()
+	if err != nil {
+		return nil
+	}
+
+	var wincases []types.Wincase
+	for _, id := range []types.WincaseID{types.WincaseID(2 * m.GetID()), types.WincaseID(2*m.GetID() + 1)} {
+		name, ok := types.WincaseNames[id]
+		if !ok || !strings.HasPrefix(name, m.GetName()+"::") {
+			continue
+		}
+
+		data, err := json.Marshal([]interface{}{name, meta})
+		if err != nil {
+			continue
+		}
+
+		var w types.Wincase
+		if err := json.Unmarshal(data, &w); err == nil {
+			wincases = append(wincases, w)
+		}
+	}
+	return wincases
+}
@@ -0,0 +1,216 @@
+package projection
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var erinBet = uuid.MustParse("d3b3a1b7-0f74-4b4c-9a1c-8a5c5b5c5d5e")
+
+func TestResolve(t *testing.T) {
+	markets := mustMarkets(t, `[["result_home", {}], ["total", {"threshold": 2500}], ["correct_score", {"home": 1, "away": 0}], ["total", {"threshold": 1500}]]`)
+	results := mustWincases(t, `[["result_home::yes", {}], ["total::under", {"threshold": 2500}], ["correct_score::no", {"home": 1, "away": 0}]]`)
+
+	resolution := Resolve(markets, results)
+
+	var outcomes []string
+	for _, o := range resolution.Wincases() {
+		data, err := json.Marshal(o.Wincase)
+		require.NoError(t, err)
+		outcomes = append(outcomes, string(data)+" "+string(o.Outcome))
+	}
+	require.Equal(t, []string{
+		`["result_home::yes",{}] win`,
+		`["result_home::no",{}] lose`,
+		`["total::over",{"threshold":2500}] lose`,
+		`["total::under",{"threshold":2500}] win`,
+		`["correct_score::yes",{"home":1,"away":0}] lose`,
+		`["correct_score::no",{"home":1,"away":0}] win`,
+		// no results are posted for the market
+		`["total::over",{"threshold":1500}] draw`,
+		`["total::under",{"threshold":1500}] draw`,
+	}, outcomes)
+
+	// the market of a wincase is told by its meta
+	require.Equal(t, OutcomeDraw, resolution.Outcome(mustWincase(t, `["correct_score::yes", {"home": 2, "away": 0}]`)))
+
+	bet := Bet{Wincase: mustWincase(t, `["result_home::yes", {}]`), Matches: []Match{
+		{Stake: mustAsset(t, "1.000000000 SCR"), CounterStake: mustAsset(t, "2.000000000 SCR")},
+		{Stake: mustAsset(t, "0.500000000 SCR"), CounterStake: mustAsset(t, "1.000000000 SCR")},
+	}}
+	require.Equal(t, "4.500000000 SCR", resolution.ExpectedIncome(bet).String())
+
+	bet.Wincase = mustWincase(t, `["total::under", {"threshold": 1500}]`)
+	require.Equal(t, "1.500000000 SCR", resolution.ExpectedIncome(bet).String())
+
+	bet.Wincase = mustWincase(t, `["total::over", {"threshold": 2500}]`)
+	require.Equal(t, "0.000000000 SCR", resolution.ExpectedIncome(bet).String())
+}
+
+func resolvedBet(t *testing.T, id uuid.UUID, better, income string, kind types.BetResolveKind) event.Event {
+	return event.BetResolvedEvent{BetResolvedOperation: types.BetResolvedOperation{
+		GameUUID: gameUUID, Better: better, BetUUID: id, Income: mustAsset(t, income), Kind: kind,
+	}}
+}
+
+func TestSettlement(t *testing.T) {
+	settlement := NewSettlement(NewGames(), NewBets())
+
+	blocks := []event.Block{
+		{BlockNum: 1, Events: []event.Event{
+			createGame(t),
+			postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "10.000000000 SCR"),
+			postBet(t, carolBet, "carol", `["result_home::no", {}]`, "4.000000000 SCR"),
+			postBet(t, daveBet, "dave", `["total::over", {"threshold": 2500}]`, "1.000000000 SCR"),
+			postBet(t, erinBet, "erin", `["total::under", {"threshold": 2500}]`, "1.000000000 SCR"),
+			betsMatched(t, bobBet, carolBet, "bob", "carol", "4.000000000 SCR", "4.000000000 SCR", 1),
+			betsMatched(t, daveBet, erinBet, "dave", "erin", "1.000000000 SCR", "1.000000000 SCR", 2),
+		}},
+		{BlockNum: 2, Events: []event.Event{statusChanged(types.GameStatusCreated, types.GameStatusStarted)}},
+		{BlockNum: 3, Events: []event.Event{
+			event.PostGameResultsEvent{PostGameResultsOperation: types.PostGameResultsOperation{
+				UUID: gameUUID, Moderator: "alice", Wincases: mustWincases(t, `[["result_home::yes", {}]]`),
+			}},
+			statusChanged(types.GameStatusStarted, types.GameStatusFinished),
+		}},
+	}
+	for _, block := range blocks {
+		discrepancies, err := settlement.Apply(block)
+		require.NoError(t, err)
+		require.Empty(t, discrepancies)
+	}
+
+	// carol is paid instead of bob and erin's stake is not returned
+	discrepancies, err := settlement.Apply(event.Block{BlockNum: 4, Events: []event.Event{
+		resolvedBet(t, carolBet, "carol", "8.000000000 SCR", types.WinBetResolveKind),
+		resolvedBet(t, daveBet, "dave", "1.000000000 SCR", types.DrawBetResolveKind),
+		statusChanged(types.GameStatusFinished, types.GameStatusResolved),
+	}})
+	require.NoError(t, err)
+	require.Len(t, discrepancies, 3)
+
+	require.Equal(t, bobBet, discrepancies[0].BetUUID)
+	require.Equal(t, uint32(4), discrepancies[0].BlockNum)
+	require.Equal(t, gameUUID, discrepancies[0].GameUUID)
+	require.Equal(t, OutcomeWin, discrepancies[0].Outcome)
+	require.Equal(t, "8.000000000 SCR", discrepancies[0].ExpectedIncome.String())
+	require.Equal(t, BetLost, discrepancies[0].Status)
+	require.Equal(t, "0.000000000 SCR", discrepancies[0].Income.String())
+
+	require.Equal(t, carolBet, discrepancies[1].BetUUID)
+	require.Equal(t, OutcomeLose, discrepancies[1].Outcome)
+	require.Equal(t, "0.000000000 SCR", discrepancies[1].ExpectedIncome.String())
+	require.Equal(t, BetWon, discrepancies[1].Status)
+	require.Equal(t, "8.000000000 SCR", discrepancies[1].Income.String())
+
+	require.Equal(t, erinBet, discrepancies[2].BetUUID)
+	require.Equal(t, OutcomeDraw, discrepancies[2].Outcome)
+	require.Equal(t, "1.000000000 SCR", discrepancies[2].ExpectedIncome.String())
+	require.Equal(t, BetLost, discrepancies[2].Status)
+
+	// block 4 is replaced after a fork by the payouts of the node
+	discrepancies, err = settlement.Apply(event.Block{BlockNum: 4, Events: []event.Event{
+		resolvedBet(t, bobBet, "bob", "8.000000000 SCR", types.WinBetResolveKind),
+		resolvedBet(t, daveBet, "dave", "1.000000000 SCR", types.DrawBetResolveKind),
+		resolvedBet(t, erinBet, "erin", "1.000000000 SCR", types.DrawBetResolveKind),
+		statusChanged(types.GameStatusFinished, types.GameStatusResolved),
+	}})
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	require.NoError(t, settlement.Rollback(3))
+	bet, _ := settlement.bets.Bet(bobBet)
+	require.Equal(t, BetPartiallyMatched, bet.Status)
+
+	settlement.Irreversible(3)
+	require.ErrorIs(t, settlement.Rollback(2), ErrIrreversible)
+}
+
+func TestSettlement_Atomic(t *testing.T) {
+	games := NewGames()
+	settlement := NewSettlement(games, NewBets())
+
+	var changes []GameChange
+	games.Subscribe(func(change GameChange) {
+		changes = append(changes, change)
+	})
+
+	_, err := settlement.Apply(event.Block{BlockNum: 1, Events: []event.Event{
+		createGame(t),
+		postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "4.000000000 SCR"),
+		postBet(t, carolBet, "carol", `["result_home::no", {}]`, "4.000000000 SCR"),
+	}})
+	require.NoError(t, err)
+
+	// the game skips started, the status of the node and the bets of the block are applied
+	_, err = settlement.Apply(event.Block{BlockNum: 2, Events: []event.Event{
+		statusChanged(types.GameStatusCreated, types.GameStatusFinished),
+		betsMatched(t, bobBet, carolBet, "bob", "carol", "4.000000000 SCR", "4.000000000 SCR", 1),
+	}})
+	require.NoError(t, err)
+
+	game, _ := settlement.games.Game(gameUUID)
+	require.Equal(t, types.GameStatusFinished, game.Status)
+	requireBet(t, settlement.bets, bobBet, BetMatched, "0.000000000 SCR", "4.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+
+	require.Len(t, changes, 2)
+	changes = nil
+
+	// the bets can't apply the block, the games revert it and their subscribers aren't notified
+	_, err = settlement.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		event.PostGameResultsEvent{PostGameResultsOperation: types.PostGameResultsOperation{
+			UUID: gameUUID, Moderator: "alice", Wincases: mustWincases(t, `[["result_home::yes", {}]]`),
+		}},
+		resolvedBet(t, bobBet, "bob", "8.000000000 SCR", "refund"),
+		statusChanged(types.GameStatusFinished, types.GameStatusResolved),
+	}})
+	require.ErrorIs(t, err, ErrUnknownResolveKind)
+
+	game, _ = settlement.games.Game(gameUUID)
+	require.Equal(t, types.GameStatusFinished, game.Status)
+	require.Nil(t, game.Results)
+	require.Equal(t, uint32(2), game.UpdatedAt)
+	requireBet(t, settlement.bets, bobBet, BetMatched, "0.000000000 SCR", "4.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+	require.Empty(t, changes)
+
+	discrepancies, err := settlement.Apply(event.Block{BlockNum: 3, Events: []event.Event{
+		event.PostGameResultsEvent{PostGameResultsOperation: types.PostGameResultsOperation{
+			UUID: gameUUID, Moderator: "alice", Wincases: mustWincases(t, `[["result_home::yes", {}]]`),
+		}},
+		resolvedBet(t, bobBet, "bob", "8.000000000 SCR", types.WinBetResolveKind),
+		statusChanged(types.GameStatusFinished, types.GameStatusResolved),
+	}})
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+	requireBet(t, settlement.bets, carolBet, BetLost, "0.000000000 SCR", "4.000000000 SCR", "0.000000000 SCR", "0.000000000 SCR")
+}
+
+func TestSettlement_AtomicFirstBlock(t *testing.T) {
+	games := NewGames()
+	settlement := NewSettlement(games, NewBets())
+
+	var changes []GameChange
+	games.Subscribe(func(change GameChange) {
+		changes = append(changes, change)
+	})
+
+	_, err := settlement.Apply(event.Block{BlockNum: 0, Events: []event.Event{
+		createGame(t),
+		postBet(t, bobBet, "bob", `["result_home::yes", {}]`, "4.000000000 SCR"),
+		resolvedBet(t, bobBet, "bob", "4.000000000 SCR", "refund"),
+	}})
+	require.ErrorIs(t, err, ErrUnknownResolveKind)
+
+	_, ok := games.Game(gameUUID)
+	require.False(t, ok)
+	require.Empty(t, changes)
+
+	_, err = settlement.Apply(event.Block{BlockNum: 0, Events: []event.Event{createGame(t)}})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+}
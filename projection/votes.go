@@ -0,0 +1,112 @@
+package projection
+
+import "github.com/scorum/event-provider-go/event"
+
+// VoteKey identifies the vote of a voter on a post or a comment
+type VoteKey struct {
+	Voter    string
+	Author   string
+	PermLink string
+}
+
+// Votes keeps the last weight of every vote and flag. A vote operation only carries the new weight,
+// Votes tells a new vote from a changed or a removed one.
+//
+// Votes seen before the first applied block are unknown, a change of such a vote is reported as a new one.
+type Votes struct {
+	history
+	weights map[VoteKey]int16
+}
+
+func NewVotes() *Votes {
+	return &Votes{
+		weights: make(map[VoteKey]int16),
+	}
+}
+
+// Apply updates the weights with the vote and flag events of the block and returns the events of the changes:
+// VoteCastEvent, VoteChangedEvent, VoteRemovedEvent, FlagCastEvent and FlagRemovedEvent.
+// A vote replaced by a flag, or a flag replaced by a vote, is both removed and cast.
+// A block at or below the last applied block is a fork, the blocks from it on are rolled back first.
+func (v *Votes) Apply(block event.Block) ([]event.Event, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if err := v.journal.begin(block.BlockNum); err != nil {
+		return nil, err
+	}
+
+	var events []event.Event
+	for _, e := range block.Events {
+		switch e := e.(type) {
+		case *event.VoteEvent:
+			events = append(events, v.set(VoteKey{e.Voter, e.Author, e.PermLink}, e.Weight)...)
+		case *event.FlagEvent:
+			events = append(events, v.set(VoteKey{e.Voter, e.Author, e.PermLink}, e.Weight)...)
+		}
+	}
+
+	return events, nil
+}
+
+func (v *Votes) set(key VoteKey, weight int16) []event.Event {
+	previous, exists := v.weights[key]
+
+	if weight == 0 {
+		delete(v.weights, key)
+	} else {
+		v.weights[key] = weight
+	}
+
+	v.journal.record(func() {
+		if exists {
+			v.weights[key] = previous
+		} else {
+			delete(v.weights, key)
+		}
+	})
+
+	var events []event.Event
+
+	switch {
+	case previous > 0 && weight <= 0:
+		events = append(events, &event.VoteRemovedEvent{
+			Voter: key.Voter, Author: key.Author, PermLink: key.PermLink, PreviousWeight: previous, Weight: weight,
+		})
+	case previous < 0 && weight >= 0:
+		events = append(events, &event.FlagRemovedEvent{
+			Voter: key.Voter, Author: key.Author, PermLink: key.PermLink, PreviousWeight: previous, Weight: weight,
+		})
+	case previous == 0 && weight == 0:
+		// an unvote of a vote seen before the first applied block
+		events = append(events, &event.VoteRemovedEvent{
+			Voter: key.Voter, Author: key.Author, PermLink: key.PermLink,
+		})
+	}
+
+	switch {
+	case weight > 0 && previous > 0:
+		events = append(events, &event.VoteChangedEvent{
+			Voter: key.Voter, Author: key.Author, PermLink: key.PermLink, PreviousWeight: previous, Weight: weight,
+		})
+	case weight > 0:
+		events = append(events, &event.VoteCastEvent{
+			Voter: key.Voter, Author: key.Author, PermLink: key.PermLink, PreviousWeight: previous, Weight: weight,
+		})
+	case weight < 0:
+		events = append(events, &event.FlagCastEvent{
+			Voter: key.Voter, Author: key.Author, PermLink: key.PermLink, PreviousWeight: previous, Weight: weight,
+		})
+	}
+
+	return events
+}
+
+// Weight returns the weight of the vote, negative for a flag, false if there is no vote
+func (v *Votes) Weight(key VoteKey) (int16, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	weight, ok := v.weights[key]
+	return weight, ok
+}
@@ -0,0 +1,88 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/scorum/event-provider-go/event"
+	"github.com/stretchr/testify/require"
+)
+
+func vote(voter string, weight int16) event.Event {
+	if weight < 0 {
+		return &event.FlagEvent{Voter: voter, Author: "alice", PermLink: "post", Weight: weight}
+	}
+	return &event.VoteEvent{Voter: voter, Author: "alice", PermLink: "post", Weight: weight}
+}
+
+func TestVotes(t *testing.T) {
+	votes := NewVotes()
+	bob := VoteKey{Voter: "bob", Author: "alice", PermLink: "post"}
+
+	cases := []struct {
+		weight   int16
+		expected []event.Event
+	}{
+		{10000, []event.Event{&event.VoteCastEvent{Voter: "bob", Author: "alice", PermLink: "post", Weight: 10000}}},
+		{5000, []event.Event{&event.VoteChangedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 10000, Weight: 5000}}},
+		{0, []event.Event{&event.VoteRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 5000}}},
+		{-5000, []event.Event{&event.FlagCastEvent{Voter: "bob", Author: "alice", PermLink: "post", Weight: -5000}}},
+		{-10000, []event.Event{&event.FlagCastEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: -5000, Weight: -10000}}},
+		{10000, []event.Event{
+			&event.FlagRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: -10000, Weight: 10000},
+			&event.VoteCastEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: -10000, Weight: 10000},
+		}},
+		{-10000, []event.Event{
+			&event.VoteRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 10000, Weight: -10000},
+			&event.FlagCastEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 10000, Weight: -10000},
+		}},
+		{0, []event.Event{&event.FlagRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: -10000}}},
+	}
+
+	for i, c := range cases {
+		events, err := votes.Apply(event.Block{BlockNum: uint32(i + 1), Events: []event.Event{vote("bob", c.weight)}})
+		require.NoError(t, err)
+		require.Equal(t, c.expected, events, i)
+
+		weight, ok := votes.Weight(bob)
+		require.Equal(t, c.weight != 0, ok)
+		require.Equal(t, c.weight, weight)
+	}
+
+	// an unvote of a vote cast before the first applied block
+	events, err := NewVotes().Apply(event.Block{BlockNum: 1, Events: []event.Event{vote("bob", 0)}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{&event.VoteRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post"}}, events)
+}
+
+func TestVotes_Rollback(t *testing.T) {
+	votes := NewVotes()
+	bob := VoteKey{Voter: "bob", Author: "alice", PermLink: "post"}
+	carol := VoteKey{Voter: "carol", Author: "alice", PermLink: "post"}
+
+	_, err := votes.Apply(event.Block{BlockNum: 10, Events: []event.Event{vote("bob", 10000)}})
+	require.NoError(t, err)
+	_, err = votes.Apply(event.Block{BlockNum: 11, Events: []event.Event{vote("bob", 5000), vote("carol", -10000)}})
+	require.NoError(t, err)
+
+	// block 11 is replaced after a fork, the change of the vote is seen against the vote of block 10
+	events, err := votes.Apply(event.Block{BlockNum: 11, Events: []event.Event{vote("bob", 0)}})
+	require.NoError(t, err)
+	require.Equal(t, []event.Event{&event.VoteRemovedEvent{Voter: "bob", Author: "alice", PermLink: "post", PreviousWeight: 10000}}, events)
+
+	_, ok := votes.Weight(carol)
+	require.False(t, ok)
+
+	require.NoError(t, votes.Rollback(10))
+	weight, ok := votes.Weight(bob)
+	require.True(t, ok)
+	require.Equal(t, int16(10000), weight)
+
+	votes.Irreversible(10)
+	require.ErrorIs(t, votes.Rollback(9), ErrIrreversible)
+
+	_, err = votes.Apply(event.Block{BlockNum: 10, Events: []event.Event{vote("bob", 0)}})
+	require.ErrorIs(t, err, ErrIrreversible)
+
+	weight, _ = votes.Weight(bob)
+	require.Equal(t, int16(10000), weight)
+}
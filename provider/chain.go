@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"github.com/scorum/event-provider-go/event"
+	"github.com/scorum/scorum-go/apis/chain"
+)
+
+// ChainState is the state of the chain versions and median properties the change events are computed against
+type ChainState struct {
+	HFVersion       string
+	MajorityVersion string
+	Properties      event.ChainProperties
+}
+
+// chainWatcher tracks the chain versions and median properties between polls
+type chainWatcher struct {
+	// state is nil until the first call, or the state of the checkpoint the provider is resumed from
+	state *ChainState
+}
+
+// observe returns the events describing what changed since the previous call and the observed state.
+// Without a previous state the call only remembers the state.
+func (w *chainWatcher) observe(properties *chain.ChainProperties) ([]event.Event, ChainState) {
+	current := ChainState{
+		HFVersion:       properties.HFVersion,
+		MajorityVersion: properties.MajorityVersion,
+		Properties: event.ChainProperties{
+			AccountCreationFee: properties.MedianChainProperies.AccountCreationFee,
+			MaximumBlockSize:   properties.MedianChainProperies.MaximumBlockSize,
+		},
+	}
+
+	var events []event.Event
+
+	if w.state != nil {
+		if w.state.HFVersion != current.HFVersion {
+			events = append(events, &event.HardforkVersionChangedEvent{
+				OldVersion: w.state.HFVersion,
+				NewVersion: current.HFVersion,
+			})
+		}
+
+		if w.state.MajorityVersion != current.MajorityVersion {
+			events = append(events, &event.MajorityVersionChangedEvent{
+				OldVersion: w.state.MajorityVersion,
+				NewVersion: current.MajorityVersion,
+			})
+		}
+
+		if !w.state.Properties.Equals(current.Properties) {
+			events = append(events, &event.ChainPropertiesChangedEvent{
+				Old: w.state.Properties,
+				New: current.Properties,
+			})
+		}
+	}
+
+	w.state = &current
+
+	return events, current
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scorum/scorum-go/types"
+)
+
+// fakeNode is an in-memory scorum node serving the rpc methods used by the provider
+type fakeNode struct {
+	mu sync.Mutex
+
+	chainID  string
+	head     uint32
+	lib      uint32
+	accounts []string
+	blocks   map[uint32][]fakeOp
+	// skipped blocks are absent in get_blocks responses
+	skipped map[uint32]bool
+	// lost blocks are not served at all
+	lost map[uint32]bool
+	// maxRequested is the highest block number requested by get_blocks
+	maxRequested uint32
+
+	hfVersion          string
+	majorityVersion    string
+	accountCreationFee string
+	maximumBlockSize   uint32
+}
+
+type fakeOp struct {
+	Type types.OpType
+	Data interface{}
+}
+
+func newFakeNode(chainID string, head, lib uint32) *fakeNode {
+	return &fakeNode{
+		chainID:            chainID,
+		head:               head,
+		lib:                lib,
+		blocks:             make(map[uint32][]fakeOp),
+		skipped:            make(map[uint32]bool),
+		lost:               make(map[uint32]bool),
+		hfVersion:          "0.5.0",
+		majorityVersion:    "0.5.0",
+		accountCreationFee: "0.750000000 SCR",
+		maximumBlockSize:   65536,
+	}
+}
+
+func (n *fakeNode) addOp(blockNum uint32, opType types.OpType, data interface{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.blocks[blockNum] = append(n.blocks[blockNum], fakeOp{Type: opType, Data: data})
+}
+
+func (n *fakeNode) setHead(head, lib uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.head = head
+	n.lib = lib
+}
+
+func (n *fakeNode) setVersions(hfVersion, majorityVersion string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.hfVersion = hfVersion
+	n.majorityVersion = majorityVersion
+}
+
+func (n *fakeNode) timestamp(blockNum uint32) string {
+	return time.Unix(1600000000+int64(blockNum)*3, 0).UTC().Format(timeLayout)
+}
+
+func (n *fakeNode) block(blockNum uint32) map[string]interface{} {
+	ops := make([]interface{}, 0, len(n.blocks[blockNum]))
+	for _, op := range n.blocks[blockNum] {
+		ops = append(ops, map[string]interface{}{
+			"trx_id":    fmt.Sprintf("trx%d", blockNum),
+			"timestamp": n.timestamp(blockNum),
+			"op":        []interface{}{op.Type, op.Data},
+		})
+	}
+
+	return map[string]interface{}{
+		"block_num":  blockNum,
+		"timestamp":  n.timestamp(blockNum),
+		"operations": ops,
+	}
+}
+
+func (n *fakeNode) Call(ctx context.Context, api string, method string, args []interface{}, reply interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var resp interface{}
+
+	switch method {
+	case "get_chain_properties":
+		resp = map[string]interface{}{
+			"chain_id":                       n.chainID,
+			"head_block_number":              n.head,
+			"last_irreversible_block_number": n.lib,
+			"time":                           n.timestamp(n.head),
+			"hf_version":                     n.hfVersion,
+			"majority_version":               n.majorityVersion,
+			"median_chain_props": map[string]interface{}{
+				"account_creation_fee": n.accountCreationFee,
+				"maximum_block_size":   n.maximumBlockSize,
+			},
+		}
+	case "get_blocks":
+		// the node serves the window (blockNum-limit, blockNum], clamped to the head block
+		blockNum, limit := args[0].(uint32), args[1].(uint32)
+		if blockNum > n.maxRequested {
+			n.maxRequested = blockNum
+		}
+		if blockNum > n.head {
+			blockNum = n.head
+		}
+
+		blocks := make([]interface{}, 0, limit)
+		for num := blockNum; num > 0 && num+limit > blockNum; num-- {
+			if !n.skipped[num] && !n.lost[num] {
+				blocks = append(blocks, n.block(num))
+			}
+		}
+		resp = blocks
+	case "get_ops_in_block":
+		blockNum := args[0].(uint32)
+
+		ops := make([]interface{}, 0)
+		if !n.lost[blockNum] {
+			for i, op := range n.blocks[blockNum] {
+				ops = append(ops, []interface{}{i, map[string]interface{}{
+					"block":     blockNum,
+					"trx_id":    fmt.Sprintf("trx%d", blockNum),
+					"timestamp": n.timestamp(blockNum),
+					"op":        []interface{}{op.Type, op.Data},
+				}})
+			}
+		}
+		resp = ops
+	case "get_block":
+		blockNum := args[0].(uint32)
+
+		block := map[string]interface{}{}
+		if !n.lost[blockNum] && blockNum <= n.head {
+			block["timestamp"] = n.timestamp(blockNum)
+		}
+		resp = block
+	case "lookup_accounts":
+		lowerBound, limit := args[0].(string), int(args[1].(uint16))
+
+		accounts := make([]string, 0, limit)
+		for _, account := range n.accounts {
+			if account >= lowerBound && len(accounts) < limit {
+				accounts = append(accounts, account)
+			}
+		}
+		resp = accounts
+	default:
+		return fmt.Errorf("fake node: unsupported method %s.%s", api, method)
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, reply)
+}
+
+func (n *fakeNode) SetCallback(api string, method string, callback func(raw json.RawMessage)) error {
+	return nil
+}
+
+func (n *fakeNode) Close() error {
+	return nil
+}
@@ -2,7 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/scorum/event-provider-go/event"
@@ -11,6 +15,7 @@ import (
 	"github.com/scorum/scorum-go/apis/chain"
 	"github.com/scorum/scorum-go/caller"
 	"github.com/scorum/scorum-go/rpc"
+	"github.com/scorum/scorum-go/types"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -18,6 +23,36 @@ const (
 	timeLayout = "2006-01-02T15:04:05"
 )
 
+const (
+	// MainNetChainID is the chain id of the Scorum mainnet, see sign.MainNetChainID
+	MainNetChainID = "db4007d45f04c1403a7e66a5c66b5b1cdfc2dde8b5335d1d2f116d592ca3dbb1"
+	// TestNetChainID is the chain id of the Scorum testnet, see sign.TestNetChainID
+	TestNetChainID = "d3c1f19a4947c296446583f988c43fd1a83818fabaf3454a0020198cb361ebd2"
+)
+
+var (
+	// ErrChainIDMismatch is returned when the node serves a chain other than Options.ChainID
+	ErrChainIDMismatch = errors.New("chain id mismatch")
+	// ErrMissingBlock is returned when a block of the requested history can not be obtained from the node
+	ErrMissingBlock = errors.New("missing block")
+)
+
+// ConversionError is returned when an operation can not be converted into an event
+type ConversionError struct {
+	BlockNum      uint32
+	TransactionID string
+	OpType        types.OpType
+	Err           error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("block %d, transaction %s: can't convert %s operation: %s", e.BlockNum, e.TransactionID, e.OpType, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
 type Options struct {
 	// SyncInterval is an interval to poll the blockchain
 	SyncInterval          time.Duration
@@ -25,6 +60,17 @@ type Options struct {
 	ErrorRetryTimeout     time.Duration
 	ErrorRetryLimit       int
 	ProvideEmptyBlocks    bool
+	// ChainID is the expected chain id of the node, the provider refuses to stream on mismatch.
+	// Empty value disables the check
+	ChainID string
+	// ConfirmationDepth is the number of blocks a block has to be below the head block
+	// to be provided as confirmed
+	ConfirmationDepth uint32
+	// Registry converts operations into events
+	Registry *event.Registry
+	// SkipConversionErrors logs and skips operations that can not be converted
+	// instead of stopping with ConversionError
+	SkipConversionErrors bool
 }
 
 type Option func(*Options)
@@ -59,10 +105,47 @@ func ProvideEmptyBlocks(v bool) Option {
 	}
 }
 
+func ChainID(chainID string) Option {
+	return func(args *Options) {
+		args.ChainID = chainID
+	}
+}
+
+func ConfirmationDepth(depth uint32) Option {
+	return func(args *Options) {
+		args.ConfirmationDepth = depth
+	}
+}
+
+func Registry(registry *event.Registry) Option {
+	return func(args *Options) {
+		args.Registry = registry
+	}
+}
+
+func SkipConversionErrors(v bool) Option {
+	return func(args *Options) {
+		args.SkipConversionErrors = v
+	}
+}
+
+// Checkpoint keeps the last processed block of every stream
+type Checkpoint struct {
+	Reversible   uint32
+	Confirmed    uint32
+	Irreversible uint32
+	// Chain is the chain state the chain change events were last computed against, nil before the first poll.
+	// Resuming with it provides the changes made while the provider was stopped.
+	Chain *ChainState
+}
+
 type Provider struct {
 	client          *scorumgo.Client
 	Options         *Options
 	CurrentBlockNum uint32
+
+	mu         sync.Mutex
+	checkpoint Checkpoint
 }
 
 func NewProviderWithClient(client caller.CallCloser, setters ...Option) *Provider {
@@ -72,6 +155,7 @@ func NewProviderWithClient(client caller.CallCloser, setters ...Option) *Provide
 		ErrorRetryTimeout:     10 * time.Second,
 		ErrorRetryLimit:       3,
 		ProvideEmptyBlocks:    false,
+		Registry:              event.DefaultRegistry,
 	}
 
 	for _, setter := range setters {
@@ -88,6 +172,21 @@ func NewProvider(url string, setters ...Option) *Provider {
 	return NewProviderWithClient(rpc.NewHTTPTransport(url), setters...)
 }
 
+// Checkpoint returns the last processed blocks, providing can be resumed from it with ProvideFromCheckpoint
+func (p *Provider) Checkpoint() Checkpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.checkpoint
+}
+
+func (p *Provider) setCheckpoint(checkpoint Checkpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checkpoint = checkpoint
+}
+
 func (p *Provider) Provide(ctx context.Context, from, irreversibleFrom uint32, eventTypes []event.Type) (chan event.Block, chan event.Block, chan error) {
 	if irreversibleFrom > from {
 		log.Warn("EventProvider: irreversibleFrom > from")
@@ -95,135 +194,333 @@ func (p *Provider) Provide(ctx context.Context, from, irreversibleFrom uint32, e
 
 	log.Infof("Provide starting... from : %d; irreversible from: %d", from, irreversibleFrom)
 
-	blocksCh := make(chan event.Block)
-	irreversibleBlocksCh := make(chan event.Block)
-	errCh := make(chan error)
+	s := streams{
+		blocks:       make(chan event.Block),
+		irreversible: make(chan event.Block),
+		errs:         make(chan error),
+	}
 
-	go func(blocksCh, irreversibleBlocksCh chan event.Block, errCh chan error) {
-		// genesis block
+	go p.provide(ctx, Checkpoint{Reversible: from, Irreversible: irreversibleFrom}, eventTypes, s)
 
-		if from == 0 {
-			accountCreateEventTypeFound := false
-			for _, eventType := range eventTypes {
-				if eventType == event.AccountCreateEventType {
-					accountCreateEventTypeFound = true
-					break
-				}
-			}
+	return s.blocks, s.irreversible, s.errs
+}
 
-			if accountCreateEventTypeFound {
-				accounts, err := p.getExistingAccounts(ctx)
-				if err != nil {
-					errCh <- err
-					return
-				}
+// ProvideIrreversible provides irreversible blocks only. Blocks are fetched up to the last irreversible block,
+// reversible blocks are neither requested from the node nor tracked.
+func (p *Provider) ProvideIrreversible(ctx context.Context, from uint32, eventTypes []event.Type) (chan event.Block, chan error) {
+	log.Infof("ProvideIrreversible starting... from : %d", from)
 
-				// genesis block
-				genesis := event.Block{
-					BlockNum:  0,
-					Timestamp: time.Unix(0, 0),
-				}
+	s := streams{
+		irreversible: make(chan event.Block),
+		errs:         make(chan error),
+	}
+
+	go p.provide(ctx, Checkpoint{Reversible: from, Irreversible: from}, eventTypes, s)
+
+	return s.irreversible, s.errs
+}
+
+// ProvideFromCheckpoint provides reversible, confirmed and irreversible blocks.
+// A block is confirmed once it is Options.ConfirmationDepth blocks below the head block.
+// A checkpoint without a confirmed block, e.g. saved before the confirmed stream was used, confirms from its irreversible block.
+func (p *Provider) ProvideFromCheckpoint(ctx context.Context, checkpoint Checkpoint, eventTypes []event.Type) (chan event.Block, chan event.Block, chan event.Block, chan error) {
+	if checkpoint.Confirmed == 0 {
+		checkpoint.Confirmed = checkpoint.Irreversible
+	}
+
+	log.Infof("ProvideFromCheckpoint starting... from : %d; confirmed from: %d; irreversible from: %d",
+		checkpoint.Reversible, checkpoint.Confirmed, checkpoint.Irreversible)
+
+	s := streams{
+		blocks:       make(chan event.Block),
+		confirmed:    make(chan event.Block),
+		irreversible: make(chan event.Block),
+		errs:         make(chan error),
+	}
+
+	go p.provide(ctx, checkpoint, eventTypes, s)
+
+	return s.blocks, s.confirmed, s.irreversible, s.errs
+}
+
+// streams are the channels blocks are provided to, a nil channel disables the stream
+type streams struct {
+	blocks       chan event.Block
+	confirmed    chan event.Block
+	irreversible chan event.Block
+	errs         chan error
+}
+
+// stream is a channel with the last block provided to it and the last block it may be provided up to
+type stream struct {
+	ch     chan event.Block
+	cursor *uint32
+	target uint32
+}
+
+// provide polls the blockchain and provides blocks to the enabled streams
+func (p *Provider) provide(ctx context.Context, checkpoint Checkpoint, eventTypes []event.Type, s streams) {
+	p.setCheckpoint(checkpoint)
+
+	if p.Options.ChainID != "" {
+		properties, err := p.getChainProperties(ctx)
+		if err != nil {
+			s.errs <- err
+			return
+		}
+
+		if err := p.checkChainID(properties); err != nil {
+			s.errs <- err
+			return
+		}
+	}
+
+	// genesis block
 
-				for _, account := range accounts {
-					genesis.Events = append(genesis.Events,
-						&event.AccountCreateEvent{
-							Account: account,
-						})
+	if checkpoint.Reversible == 0 {
+		if containsType(eventTypes, event.AccountCreateEventType) {
+			accounts, err := p.getExistingAccounts(ctx)
+			if err != nil {
+				s.errs <- err
+				return
+			}
 
+			// genesis block
+			genesis := event.Block{
+				BlockNum:  0,
+				Timestamp: time.Unix(0, 0),
+			}
+
+			for _, account := range accounts {
+				genesis.Events = append(genesis.Events,
+					&event.AccountCreateEvent{
+						Account: account,
+					})
+
+			}
+			for _, ch := range []chan event.Block{s.blocks, s.confirmed, s.irreversible} {
+				if ch != nil {
+					ch <- genesis
 				}
-				blocksCh <- genesis
-				irreversibleBlocksCh <- genesis
 			}
 		}
+	}
+
+	watcher := chainWatcher{state: checkpoint.Chain}
+	// chain events observed by polling and the chain state after them, keyed by the block they are provided with
+	chainEvents := make(map[uint32][]event.Event)
+	chainStates := make(map[uint32]ChainState)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			properties, err := p.getChainProperties(ctx)
+
+			if err != nil {
+				s.errs <- err
+				return
+			}
 
-		for {
-			select {
-			case <-ctx.Done():
+			if err := p.checkChainID(properties); err != nil {
+				s.errs <- err
 				return
-			default:
-				properties, err := p.getChainProperties(ctx)
+			}
 
-				if err != nil {
-					errCh <- err
-					return
+			var enabled []stream
+			if s.blocks != nil {
+				enabled = append(enabled, stream{s.blocks, &checkpoint.Reversible, properties.HeadBlockNumber})
+			}
+			if s.confirmed != nil {
+				confirmed := uint32(0)
+				if properties.HeadBlockNumber > p.Options.ConfirmationDepth {
+					confirmed = properties.HeadBlockNumber - p.Options.ConfirmationDepth
+				}
+				enabled = append(enabled, stream{s.confirmed, &checkpoint.Confirmed, confirmed})
+			}
+			enabled = append(enabled, stream{s.irreversible, &checkpoint.Irreversible, properties.LastIrreversibleBlockNumber})
+
+			// the last block to fetch, the most advanced provided block and the streams ordered by cursor
+			head, top := uint32(0), uint32(0)
+			byCursor := make([]stream, len(enabled))
+			copy(byCursor, enabled)
+			sort.SliceStable(byCursor, func(i, j int) bool { return *byCursor[i].cursor < *byCursor[j].cursor })
+			for _, st := range enabled {
+				if st.target > head {
+					head = st.target
 				}
+				if *st.cursor > top {
+					top = *st.cursor
+				}
+			}
 
-				if from >= properties.HeadBlockNumber {
-					time.Sleep(p.Options.SyncInterval)
+			changes, state := watcher.observe(properties)
+			if len(changes) != 0 {
+				// the change is observed at the head block, or at the next block if the head is already provided
+				at := head
+				if at <= top {
+					at = top + 1
+				}
+				chainEvents[at] = append(chainEvents[at], changes...)
+				chainStates[at] = state
+			} else if checkpoint.Chain == nil {
+				checkpoint.Chain = &state
+				p.setCheckpoint(checkpoint)
+			}
+
+			// GetBlockHistory has descending order, every lagging stream needs a window starting at its cursor
+			history := make(blockchain_history.Blocks)
+			for _, st := range byCursor {
+				if *st.cursor >= st.target {
+					continue
+				}
+				if _, fetched := history[*st.cursor+1]; fetched {
 					continue
 				}
 
-				// GetBlockHistory has descending order
-				limit := properties.HeadBlockNumber - irreversibleFrom
+				limit := head - *st.cursor
 				if limit > p.Options.BlocksHistoryMaxLimit {
 					limit = p.Options.BlocksHistoryMaxLimit
 				}
 
-				offset := from + limit
+				offset := *st.cursor + limit
 
-				history, err := p.getBlockHistory(ctx, offset, limit)
+				window, err := p.getBlockHistory(ctx, offset, limit)
 				if err != nil {
-					errCh <- err
+					s.errs <- err
 					return
 				}
 
-				nums := make([]uint32, 0, len(history))
-				for num := range history {
-					nums = append(nums, num)
+				if err := p.fillGaps(ctx, window, offset, limit, head); err != nil {
+					s.errs <- err
+					return
 				}
-				sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
 
-				for _, num := range nums {
-					p.CurrentBlockNum = num
+				for num, block := range window {
+					history[num] = block
+				}
+			}
+
+			if len(history) == 0 {
+				time.Sleep(p.Options.SyncInterval)
+				continue
+			}
+
+			nums := make([]uint32, 0, len(history))
+			for num := range history {
+				nums = append(nums, num)
+			}
+			sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+			for _, num := range nums {
+				p.CurrentBlockNum = num
+
+				block := history[num]
+
+				timestamp, err := time.Parse(timeLayout, block.Timestamp)
+				if err != nil {
+					s.errs <- err
+					return
+				}
 
-					block := history[num]
+				eBlock := event.Block{
+					BlockNum:  num,
+					Timestamp: timestamp,
+				}
 
-					timestamp, err := time.Parse(timeLayout, block.Timestamp)
+				for _, operation := range block.Operations {
+					ev, err := p.Options.Registry.ToEvent(operation.Operation)
 					if err != nil {
-						errCh <- err
+						err = &ConversionError{
+							BlockNum:      num,
+							TransactionID: operation.TransactionID,
+							OpType:        operation.Operation.Type(),
+							Err:           err,
+						}
+
+						if p.Options.SkipConversionErrors {
+							log.WithError(err).Warn("operation skipped")
+							continue
+						}
+
+						s.errs <- err
 						return
 					}
 
-					eBlock := event.Block{
-						BlockNum:  num,
-						Timestamp: timestamp,
+					if timed, ok := ev.(event.BlockTimed); ok {
+						timed.SetBlockTime(timestamp)
 					}
 
-					for _, operation := range block.Operations {
-						ev := event.ToEvent(operation.Operation)
-						for _, eventType := range eventTypes {
-							if ev.Type() == eventType {
-								eBlock.Events = append(eBlock.Events, ev)
-								break
-							}
-						}
+					if containsType(eventTypes, ev.Type()) {
+						eBlock.Events = append(eBlock.Events, ev)
 					}
+				}
 
-					if len(eBlock.Events) != 0 || p.Options.ProvideEmptyBlocks {
-						if num > from {
-							blocksCh <- eBlock
-						}
-
-						if num <= properties.LastIrreversibleBlockNumber && num > irreversibleFrom {
-							irreversibleBlocksCh <- eBlock
-						}
+				for _, ev := range chainEvents[num] {
+					if containsType(eventTypes, ev.Type()) {
+						eBlock.Events = append(eBlock.Events, ev)
 					}
+				}
 
-					if num > from {
-						from = num
+				for _, st := range enabled {
+					// every stream is provided with consecutive blocks up to its target
+					if num != *st.cursor+1 || num > st.target {
+						continue
 					}
-					if (num <= properties.LastIrreversibleBlockNumber) && (num > irreversibleFrom) {
-						irreversibleFrom = num
+
+					if len(eBlock.Events) != 0 || p.Options.ProvideEmptyBlocks {
+						st.ch <- eBlock
 					}
+					*st.cursor = num
 				}
 
-				time.Sleep(p.Options.SyncInterval)
+				p.setCheckpoint(checkpoint)
+			}
+
+			// chain events are kept until every stream is provided with them
+			lowest := *enabled[0].cursor
+			for _, st := range enabled {
+				if *st.cursor < lowest {
+					lowest = *st.cursor
+				}
+			}
+			// the checkpoint keeps the state after the last change provided to every stream
+			provided := uint32(0)
+			for num := range chainEvents {
+				if num <= lowest {
+					if num >= provided {
+						provided = num
+						state := chainStates[num]
+						checkpoint.Chain = &state
+					}
+					delete(chainEvents, num)
+					delete(chainStates, num)
+				}
 			}
+			p.setCheckpoint(checkpoint)
+
+			time.Sleep(p.Options.SyncInterval)
 		}
+	}
+}
+
+func containsType(eventTypes []event.Type, eventType event.Type) bool {
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
 
-	}(blocksCh, irreversibleBlocksCh, errCh)
+// checkChainID verifies that the node serves the expected chain
+func (p *Provider) checkChainID(properties *chain.ChainProperties) error {
+	if p.Options.ChainID == "" || strings.EqualFold(p.Options.ChainID, properties.ChainID) {
+		return nil
+	}
 
-	return blocksCh, irreversibleBlocksCh, errCh
+	return fmt.Errorf("%w: expected %s, got %s", ErrChainIDMismatch, p.Options.ChainID, properties.ChainID)
 }
 
 func (p *Provider) getExistingAccounts(ctx context.Context) ([]string, error) {
@@ -292,3 +589,104 @@ func (p *Provider) getBlockHistory(ctx context.Context, blockNum, limit uint32)
 	})
 	return
 }
+
+// fillGaps refetches the blocks of the window (blockNum-limit, blockNum] the history lacks.
+// Blocks above the head block are not refetched.
+func (p *Provider) fillGaps(ctx context.Context, history blockchain_history.Blocks, blockNum, limit, head uint32) error {
+	last := blockNum
+	if last > head {
+		last = head
+	}
+
+	first := uint32(1)
+	if last > limit {
+		first = last - limit + 1
+	}
+
+	for num := first; num <= last; num++ {
+		if _, exists := history[num]; exists {
+			continue
+		}
+
+		log.Warnf("block %d is missing in the blocks history, refetching", num)
+
+		block, err := p.getBlock(ctx, num)
+		if err != nil {
+			return err
+		}
+		history[num] = block
+	}
+
+	return nil
+}
+
+// getBlock fetches a single block with its operations
+func (p *Provider) getBlock(ctx context.Context, blockNum uint32) (*types.OperationsBlock, error) {
+	ops, err := p.getOperationsInBlock(ctx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	block := types.OperationsBlock{
+		BlockNum:   blockNum,
+		Operations: make([]types.OperationInfo, 0, len(ops)),
+	}
+
+	seqs := make([]uint32, 0, len(ops))
+	for seq := range ops {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	for _, seq := range seqs {
+		obj := ops[seq]
+		if obj.Timestamp.Time != nil {
+			block.Timestamp = obj.Timestamp.Format(timeLayout)
+		}
+
+		for _, op := range obj.Operations {
+			block.Operations = append(block.Operations, types.OperationInfo{
+				Operation:     op,
+				Timestamp:     obj.Timestamp,
+				TransactionID: obj.TransactionID,
+			})
+		}
+	}
+
+	// a block without operations is only known by its header
+	if block.Timestamp == "" {
+		signed, err := p.getSignedBlock(ctx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		block.Timestamp = signed.Timestamp
+	}
+
+	if block.Timestamp == "" {
+		return nil, fmt.Errorf("%w: %d", ErrMissingBlock, blockNum)
+	}
+
+	return &block, nil
+}
+
+func (p *Provider) getOperationsInBlock(ctx context.Context, blockNum uint32) (ops blockchain_history.History, err error) {
+	err = TryDo(func(attempt int) (retry bool, err error) {
+		ops, err = p.client.BlockchainHistory.GetOperationsInBlock(ctx, blockNum, blockchain_history.AllOp)
+		if err != nil {
+			time.Sleep(p.Options.ErrorRetryTimeout)
+		}
+		return attempt < p.Options.ErrorRetryLimit, err
+	})
+	return
+}
+
+func (p *Provider) getSignedBlock(ctx context.Context, blockNum uint32) (block *types.Block, err error) {
+	err = TryDo(func(attempt int) (retry bool, err error) {
+		block, err = p.client.BlockchainHistory.GetBlock(ctx, blockNum)
+		if err != nil {
+			time.Sleep(p.Options.ErrorRetryTimeout)
+		}
+		return attempt < p.Options.ErrorRetryLimit, err
+	})
+	return
+}
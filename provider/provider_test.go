@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"testing"
 	"time"
 
@@ -172,3 +174,481 @@ func TestProvider_Provide(t *testing.T) {
 		}
 	}
 }
+
+func TestChainIDConstants(t *testing.T) {
+	require.Equal(t, hex.EncodeToString(sign.MainNetChainID), MainNetChainID)
+	require.Equal(t, hex.EncodeToString(sign.TestNetChainID), TestNetChainID)
+}
+
+func TestProvider_ChainID(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 3)
+	node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+
+	provider := NewProviderWithClient(node, ChainID(TestNetChainID), SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.VoteEventType})
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-bCh:
+		require.EqualValues(t, 2, b.BlockNum)
+		require.Len(t, b.Events, 1)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}
+
+func TestProvider_ChainIDMismatch(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 3)
+	node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+
+	provider := NewProviderWithClient(node, ChainID(MainNetChainID), SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, ibCh, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.VoteEventType})
+
+	select {
+	case err := <-eCh:
+		require.True(t, errors.Is(err, ErrChainIDMismatch))
+	case b := <-bCh:
+		t.Fatalf("unexpected block %d", b.BlockNum)
+	case b := <-ibCh:
+		t.Fatalf("unexpected irreversible block %d", b.BlockNum)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error within 5 seconds")
+	}
+}
+
+func TestProvider_ChainChanges(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 3)
+	node.addOp(4, types.Hardfork, map[string]interface{}{"hardfork_id": 6})
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 3, 3, []event.Type{
+		event.HardforkEventType,
+		event.HardforkVersionChangedEventType,
+		event.MajorityVersionChangedEventType,
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	node.setVersions("0.6.0", "0.6.0")
+	node.setHead(4, 4)
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-bCh:
+		require.EqualValues(t, 4, b.BlockNum)
+		require.Equal(t, []event.Event{
+			event.HardforkEvent{HardforkID: 6},
+			&event.HardforkVersionChangedEvent{OldVersion: "0.5.0", NewVersion: "0.6.0"},
+			&event.MajorityVersionChangedEvent{OldVersion: "0.5.0", NewVersion: "0.6.0"},
+		}, b.Events)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}
+
+func TestProvider_ChainChangesFromCheckpoint(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 4, 4)
+	node.setVersions("0.6.0", "0.6.0")
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the hardfork was applied while the provider was stopped
+	fee, err := types.AssetFromString("0.750000000 SCR")
+	require.NoError(t, err)
+	checkpoint := Checkpoint{Reversible: 3, Confirmed: 3, Irreversible: 3, Chain: &ChainState{
+		HFVersion:       "0.5.0",
+		MajorityVersion: "0.6.0",
+		Properties:      event.ChainProperties{AccountCreationFee: *fee, MaximumBlockSize: 65536},
+	}}
+	bCh, cbCh, ibCh, eCh := provider.ProvideFromCheckpoint(ctx, checkpoint, []event.Type{event.HardforkVersionChangedEventType})
+
+	for received := 0; received < 3; received++ {
+		select {
+		case err := <-eCh:
+			t.Fatal(err)
+		case b := <-bCh:
+			require.EqualValues(t, 4, b.BlockNum)
+			require.Equal(t, []event.Event{&event.HardforkVersionChangedEvent{OldVersion: "0.5.0", NewVersion: "0.6.0"}}, b.Events)
+		case b := <-cbCh:
+			require.EqualValues(t, 4, b.BlockNum)
+			require.Len(t, b.Events, 1)
+		case b := <-ibCh:
+			require.EqualValues(t, 4, b.BlockNum)
+			require.Len(t, b.Events, 1)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no blocks within 5 seconds")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		chain := provider.Checkpoint().Chain
+		return chain != nil && chain.HFVersion == "0.6.0"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProvider_Gaps(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 4, 1)
+	node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+	node.skipped[2] = true
+	node.skipped[3] = true
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond), ProvideEmptyBlocks(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.VoteEventType})
+
+	for _, num := range []uint32{2, 3, 4} {
+		select {
+		case err := <-eCh:
+			t.Fatal(err)
+		case b := <-bCh:
+			require.EqualValues(t, num, b.BlockNum)
+			require.False(t, b.Timestamp.IsZero())
+			if num == 2 {
+				require.Equal(t, []event.Event{&event.VoteEvent{Voter: "alice", Author: "bob", PermLink: "post", Weight: 100}}, b.Events)
+			} else {
+				require.Empty(t, b.Events)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no blocks within 5 seconds")
+		}
+	}
+}
+
+func TestProvider_LostBlock(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 4, 4)
+	node.lost[3] = true
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond), ProvideEmptyBlocks(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.VoteEventType})
+
+	select {
+	case err := <-eCh:
+		require.True(t, errors.Is(err, ErrMissingBlock))
+	case b := <-bCh:
+		t.Fatalf("unexpected block %d", b.BlockNum)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error within 5 seconds")
+	}
+}
+
+func TestProvider_ProvideIrreversible(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 5, 3)
+	node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+	node.addOp(4, types.VoteOpType, &types.VoteOperation{Voter: "bob", Author: "alice", Permlink: "post", Weight: 100})
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ibCh, eCh := provider.ProvideIrreversible(ctx, 1, []event.Type{event.VoteEventType})
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-ibCh:
+		require.EqualValues(t, 2, b.BlockNum)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-ibCh:
+		t.Fatalf("unexpected block %d", b.BlockNum)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	node.mu.Lock()
+	require.EqualValues(t, 3, node.maxRequested)
+	node.mu.Unlock()
+
+	node.setHead(5, 4)
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-ibCh:
+		require.EqualValues(t, 4, b.BlockNum)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}
+
+func TestProvider_ProvideFromCheckpoint(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 10, 2)
+	for _, num := range []uint32{2, 5, 8} {
+		node.addOp(num, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+	}
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond), ConfirmationDepth(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, cbCh, ibCh, eCh := provider.ProvideFromCheckpoint(ctx, Checkpoint{Reversible: 1, Confirmed: 1, Irreversible: 1},
+		[]event.Type{event.VoteEventType})
+
+	var blocks, confirmed, irreversible []uint32
+	for len(blocks) < 3 || len(confirmed) < 2 || len(irreversible) < 1 {
+		select {
+		case err := <-eCh:
+			t.Fatal(err)
+		case b := <-bCh:
+			blocks = append(blocks, b.BlockNum)
+		case b := <-cbCh:
+			confirmed = append(confirmed, b.BlockNum)
+		case b := <-ibCh:
+			irreversible = append(irreversible, b.BlockNum)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no blocks within 5 seconds")
+		}
+	}
+
+	require.Equal(t, []uint32{2, 5, 8}, blocks)
+	require.Equal(t, []uint32{2, 5}, confirmed)
+	require.Equal(t, []uint32{2}, irreversible)
+	require.Eventually(t, func() bool {
+		checkpoint := provider.Checkpoint()
+		return checkpoint.Reversible == 10 && checkpoint.Confirmed == 7 && checkpoint.Irreversible == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "0.5.0", provider.Checkpoint().Chain.HFVersion)
+}
+
+func TestProvider_ProvideFromCheckpointWithoutConfirmed(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 10, 4)
+	for _, num := range []uint32{2, 5, 8} {
+		node.addOp(num, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+	}
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond), ConfirmationDepth(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the confirmed stream starts from the irreversible block instead of replaying the chain from block 1
+	bCh, cbCh, _, eCh := provider.ProvideFromCheckpoint(ctx, Checkpoint{Reversible: 5, Irreversible: 4},
+		[]event.Type{event.VoteEventType})
+
+	var blocks, confirmed []uint32
+	for len(blocks) < 1 || len(confirmed) < 1 {
+		select {
+		case err := <-eCh:
+			t.Fatal(err)
+		case b := <-bCh:
+			blocks = append(blocks, b.BlockNum)
+		case b := <-cbCh:
+			confirmed = append(confirmed, b.BlockNum)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no blocks within 5 seconds")
+		}
+	}
+
+	require.Equal(t, []uint32{8}, blocks)
+	require.Equal(t, []uint32{5}, confirmed)
+	require.Eventually(t, func() bool {
+		checkpoint := provider.Checkpoint()
+		return checkpoint.Reversible == 10 && checkpoint.Confirmed == 7 && checkpoint.Irreversible == 4
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+type customEvent struct {
+	Author string
+}
+
+var customEventType = event.NewType()
+
+func (e customEvent) Type() event.Type {
+	return customEventType
+}
+
+func TestProvider_Registry(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 1)
+	node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+
+	registry := event.NewRegistry()
+	registry.Register(types.VoteOpType, func(op types.Operation) (event.Event, error) {
+		return customEvent{Author: op.(*types.VoteOperation).Author}, nil
+	})
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond), Registry(registry))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{customEventType})
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-bCh:
+		require.EqualValues(t, 2, b.BlockNum)
+		require.Equal(t, []event.Event{customEvent{Author: "bob"}}, b.Events)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}
+
+func TestProvider_BudgetEvents(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 1)
+	node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 100})
+	node.addOp(2, types.CreateBudget, map[string]interface{}{"type": "post", "uuid": "budget", "owner": "alice", "balance": "1.000000000 SCR"})
+	node.addOp(2, types.AllocateCashFromAdvertisingBudget, map[string]interface{}{"type": "post", "uuid": "budget", "owner": "alice", "cash": "0.100000000 SCR"})
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.CreateBudgetEventType, event.AllocateCashFromAdvertisingBudgetEventType})
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-bCh:
+		require.EqualValues(t, 2, b.BlockNum)
+		require.Len(t, b.Events, 2)
+		require.Equal(t, event.CreateBudgetEventType, b.Events[0].Type())
+		require.Equal(t, "0.100000000 SCR", b.Events[1].(*event.AllocateCashFromAdvertisingBudgetEvent).Cash.String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}
+
+func TestProvider_AtomicSwapDeadline(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 1)
+	node.addOp(2, types.AtomicswapInitiateOperation, map[string]interface{}{
+		"type": "by_participant", "owner": "bob", "recipient": "alice", "amount": "1.000000000 SCR", "secret_hash": "hash",
+	})
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.AtomicSwapInitiateEventType})
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-bCh:
+		require.EqualValues(t, 2, b.BlockNum)
+		require.Len(t, b.Events, 1)
+		require.Equal(t, b.Timestamp.Add(24*time.Hour), b.Events[0].(*event.AtomicSwapInitiateEvent).Deadline)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}
+
+func TestProvider_ConversionError(t *testing.T) {
+	errConversion := errors.New("conversion failed")
+
+	registry := event.NewRegistry()
+	registry.Register(types.VoteOpType, func(op types.Operation) (event.Event, error) {
+		if op.(*types.VoteOperation).Weight == 0 {
+			return nil, errConversion
+		}
+		return event.ToEvent(op)
+	})
+
+	newNode := func() *fakeNode {
+		node := newFakeNode(TestNetChainID, 3, 1)
+		node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 0})
+		node.addOp(2, types.VoteOpType, &types.VoteOperation{Voter: "bob", Author: "alice", Permlink: "post", Weight: 100})
+		return node
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		provider := NewProviderWithClient(newNode(), SyncInterval(10*time.Millisecond), Registry(registry))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.VoteEventType})
+
+		select {
+		case err := <-eCh:
+			require.True(t, errors.Is(err, errConversion))
+
+			var conversionErr *ConversionError
+			require.True(t, errors.As(err, &conversionErr))
+			require.EqualValues(t, 2, conversionErr.BlockNum)
+			require.Equal(t, "trx2", conversionErr.TransactionID)
+			require.Equal(t, types.VoteOpType, conversionErr.OpType)
+		case b := <-bCh:
+			t.Fatalf("unexpected block %d", b.BlockNum)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no error within 5 seconds")
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		provider := NewProviderWithClient(newNode(), SyncInterval(10*time.Millisecond), Registry(registry), SkipConversionErrors(true))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.VoteEventType})
+
+		select {
+		case err := <-eCh:
+			t.Fatal(err)
+		case b := <-bCh:
+			require.EqualValues(t, 2, b.BlockNum)
+			require.Equal(t, []event.Event{&event.VoteEvent{Voter: "bob", Author: "alice", PermLink: "post", Weight: 100}}, b.Events)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no blocks within 5 seconds")
+		}
+	})
+}
+
+func TestProvider_UnknownEvent(t *testing.T) {
+	node := newFakeNode(TestNetChainID, 3, 1)
+	// an operation added by a future hardfork
+	node.addOp(2, types.OpType("future_operation"), map[string]interface{}{"owner": "alice"})
+
+	provider := NewProviderWithClient(node, SyncInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bCh, _, eCh := provider.Provide(ctx, 1, 1, []event.Type{event.UnknownEventType})
+
+	select {
+	case err := <-eCh:
+		t.Fatal(err)
+	case b := <-bCh:
+		require.EqualValues(t, 2, b.BlockNum)
+		require.Len(t, b.Events, 1)
+
+		unknown := b.Events[0].(event.UnknownEvent)
+		require.Equal(t, types.OpType("future_operation"), unknown.OpType)
+		require.JSONEq(t, `{"owner": "alice"}`, string(unknown.Data))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no blocks within 5 seconds")
+	}
+}